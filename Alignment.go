@@ -15,18 +15,38 @@ const (
 	AlignRight
 )
 
+// VAlignmentType is a vertical alignment type (see (*AlignmentSetter).VAlign).
+type VAlignmentType byte
+
+const (
+	VAlignTop VAlignmentType = iota
+	VAlignMiddle
+	VAlignBottom
+)
+
 type AlignmentSetter struct {
-	alignType AlignmentType
-	layout    Layout
-	id        string
+	alignType  AlignmentType
+	vAlignType VAlignmentType
+	layout     Layout
+	id         string
+}
+
+var _ Disposable = &alignmentState{}
+
+type alignmentState struct {
+	// height of the layout in the previous frame
+	height float32
+}
+
+// Dispose implements Disposable interface.
+func (s *alignmentState) Dispose() {
+	// noop
 }
 
 // Align sets widgets alignment.
 // usage: see examples/align
 //
 // - BUG: DatePickerWidget doesn't work properly
-// - BUG: there is some bug with SelectableWidget
-// - BUG: ComboWidget and ComboCustomWidgets doesn't work properly.
 func Align(at AlignmentType) *AlignmentSetter {
 	return &AlignmentSetter{
 		alignType: at,
@@ -40,6 +60,17 @@ func (a *AlignmentSetter) To(widgets ...Widget) *AlignmentSetter {
 	return a
 }
 
+// VAlign sets vertical alignment of the layout in the available region
+// (e.g. to center a label in a fixed-height child window).
+// Unlike the horizontal alignment, which is applied to each widget separately,
+// the whole layout is moved as a block.
+// NOTE: the layout's height is measured when it is built, so the alignment
+// is applied from the next frame.
+func (a *AlignmentSetter) VAlign(v VAlignmentType) *AlignmentSetter {
+	a.vAlignType = v
+	return a
+}
+
 // ID allows to manually set AlignmentSetter ID (it shouldn't be used
 // in a normal conditions).
 func (a *AlignmentSetter) ID(id string) *AlignmentSetter {
@@ -52,6 +83,16 @@ func (a *AlignmentSetter) Build() {
 		return
 	}
 
+	if a.vAlignType != VAlignTop {
+		state := a.getState()
+		startY := a.alignVertically(state.height)
+
+		defer func() {
+			_, spacingH := GetItemSpacing()
+			state.height = float32(GetCursorPos().Y-startY) - spacingH
+		}()
+	}
+
 	a.layout.Range(func(item Widget) {
 		// if item is inil, just skip it
 		if item == nil {
@@ -63,28 +104,33 @@ func (a *AlignmentSetter) Build() {
 		case *AlignmentSetter:
 			item.Build()
 			return
-		// there is a bug with selectables and combos, so skip them for now
-		case *SelectableWidget, *ComboWidget, *ComboCustomWidget:
-			item.Build()
-			return
 		}
 
 		currentPos := GetCursorPos()
+
 		w := GetWidgetWidth(item)
+		// selectables are as wide as available region by default,
+		// so their width needs to be set explicitly (on a copy,
+		// so that the caller's widget isn't changed)
+		if s, isSelectable := item.(*SelectableWidget); isSelectable {
+			sized := *s
+			sized.width = s.calcSize()
+			item = &sized
+		}
+
+		// the available region starts at the cursor (after window padding and indent)
+		// and ends before the right window padding and the vertical scrollbar (if any)
+		startX := imgui.CursorPosX()
 		availableW, _ := GetAvailableRegion()
-		// we need to increase available region by 2 * window padding (X),
-		// because GetCursorPos considers it
-		paddingW, _ := GetWindowPadding()
-		availableW += 2 * paddingW
 
 		// set cursor position to align the widget
 		switch a.alignType {
 		case AlignLeft:
 			SetCursorPos(currentPos)
 		case AlignCenter:
-			SetCursorPos(image.Pt(int(availableW/2-w/2), currentPos.Y))
+			SetCursorPos(image.Pt(int(startX+(availableW-w)/2), currentPos.Y))
 		case AlignRight:
-			SetCursorPos(image.Pt(int(availableW-w), currentPos.Y))
+			SetCursorPos(image.Pt(int(startX+availableW-w), currentPos.Y))
 		default:
 			panic(fmt.Sprintf("giu: (*AlignSetter).Build: unknown align type %d", a.alignType))
 		}
@@ -94,7 +140,61 @@ func (a *AlignmentSetter) Build() {
 	})
 }
 
-// GetWidgetWidth returns a width of widget
+func (a *AlignmentSetter) getState() (state *alignmentState) {
+	if s := Context.GetState(a.id); s == nil {
+		state = &alignmentState{}
+		Context.SetState(a.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*alignmentState)
+		Assert(isOk, "AlignmentSetter", "getState", "got unexpected type of widget's state")
+	}
+
+	return state
+}
+
+// alignVertically moves the cursor to place layout of the height
+// according to vertical alignment. It returns the new cursor's Y.
+func (a *AlignmentSetter) alignVertically(height float32) int {
+	currentPos := GetCursorPos()
+	_, availableH := GetAvailableRegion()
+
+	var offset float32
+
+	switch a.vAlignType {
+	case VAlignMiddle:
+		offset = (availableH - height) / 2
+	case VAlignBottom:
+		offset = availableH - height
+	default:
+		panic(fmt.Sprintf("giu: (*AlignSetter).Build: unknown vertical align type %d", a.vAlignType))
+	}
+
+	if offset <= 0 {
+		return currentPos.Y
+	}
+
+	currentPos.Y += int(offset)
+	SetCursorPos(currentPos)
+
+	return currentPos.Y
+}
+
+// Measurable is implemented by widgets able to calculate their width
+// without being built (see GetWidgetWidth).
+type Measurable interface {
+	CalcWidth() float32
+}
+
+// frameHeight returns height of a framed widget (e.g. checkbox' square).
+func frameHeight() float32 {
+	return imgui.TextLineHeight() + 2*imgui.CurrentStyle().FramePadding().Y
+}
+
+// GetWidgetWidth returns a width of widget.
+// If the widget implements Measurable, its CalcWidth is used.
+// Otherwise, the widget is built in a `dry` mode (with alpha set to 0)
+// and measured:
 // NOTE: THIS IS A BETA SOLUTION and may contain bugs
 // in most cases, you may want to use supported by imgui GetItemRectSize.
 // There is an upstream issue for this problem:
@@ -106,7 +206,7 @@ func (a *AlignmentSetter) Build() {
 // giu widget will be processed incorrectly (only width of the last built
 // widget will be processed)
 //
-// here is a list of known bugs:
+// here is a list of known bugs (of the dry mode):
 // - BUG: clicking bug - when widget is clickable, it is unable to be
 // clicked see:
 //   - https://github.com/AllenDang/giu/issues/341
@@ -117,6 +217,10 @@ func (a *AlignmentSetter) Build() {
 // if you find anything else, please report it on
 // https://github.com/AllenDang/giu Any contribution is appreciated!
 func GetWidgetWidth(w Widget) (result float32) {
+	if m, isMeasurable := w.(Measurable); isMeasurable {
+		return m.CalcWidth()
+	}
+
 	// save cursor position before rendering
 	currentPos := GetCursorPos()
 
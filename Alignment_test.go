@@ -0,0 +1,131 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Measurable(t *testing.T) {
+	newTestContext(t)
+
+	var (
+		selected int32
+		checked  bool
+	)
+
+	tests := []struct {
+		name   string
+		widget interface {
+			Widget
+			Measurable
+		}
+	}{
+		{"button", Button("button")},
+		{"sized button", Button("button").Size(100, 0)},
+		{"small button", SmallButton("small button")},
+		{"checkbox", Checkbox("checkbox", &checked)},
+		{"label", Label("label")},
+		{"label with hashes", Label("label ## with hashes")},
+		{"selectable", Selectable("selectable").Size(90, 0)},
+		{"selectable spanning all columns", Selectable("selectable").Size(90, 0).Flags(SelectableFlagsSpanAllColumns)},
+		{"combo", Combo("combo", "preview", []string{"a", "b"}, &selected)},
+		{"sized combo", Combo("combo", "preview", []string{"a", "b"}, &selected).Size(120)},
+		{"combo without label", Combo("##combo", "preview", []string{"a", "b"}, &selected).Size(120)},
+		{"combo without preview", Combo("combo", "preview", []string{"a", "b"}, &selected).Flags(ComboFlagsNoPreview)},
+		{"custom combo", ComboCustom("custom combo", "preview").Size(80)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			imgui.NewFrame()
+			imgui.Begin("measurable")
+
+			w := tc.widget.CalcWidth()
+			tc.widget.Build()
+			size := imgui.GetItemRectSize()
+
+			imgui.End()
+			imgui.Render()
+
+			assert.InDelta(t, size.X, w, 0.5)
+		})
+	}
+}
+
+func Test_AlignmentSetter_Selectable(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("aligned selectable")
+
+	selectable := Selectable("selectable")
+	expected := selectable.CalcWidth()
+
+	Align(AlignCenter).To(selectable).Build()
+	size := imgui.GetItemRectSize()
+
+	imgui.End()
+	imgui.Render()
+
+	assert.InDelta(t, expected, size.X, 0.5, "aligned selectable should be as wide as its label")
+	assert.Equal(t, float32(0), selectable.width, "aligned selectable's width shouldn't be changed")
+}
+
+func Test_AlignmentSetter_CenteredCombo(t *testing.T) {
+	io := newTestContext(t)
+
+	var (
+		comboMin, comboMax imgui.Vec2
+		popupPos           imgui.Vec2
+		popupW             float32
+		isOpen             bool
+		availableMin       float32
+		availableMax       float32
+	)
+
+	frame := func() {
+		isOpen = false
+
+		// reset every frame by cleanState; the combo's id is generated from it
+		Context.widgetIndexCounter = 0
+
+		imgui.NewFrame()
+		imgui.SetNextWindowSize(imgui.Vec2{X: 400, Y: 300})
+		imgui.Begin("centered combo")
+
+		availableMin = imgui.CursorScreenPos().X
+		availableW, _ := GetAvailableRegion()
+		availableMax = availableMin + availableW
+
+		Align(AlignCenter).To(
+			ComboCustom("##centeredCombo", "preview").Size(120).Layout(
+				Custom(func() {
+					isOpen = true
+					popupPos, popupW = imgui.WindowPos(), imgui.WindowWidth()
+				}),
+			),
+		).Build()
+		comboMin, comboMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+
+	assert.InDelta(t, comboMin.X-availableMin, availableMax-comboMax.X, 1, "combo should be centered")
+
+	// the hovered window is updated in the next frame
+	center := imgui.Vec2{X: (comboMin.X + comboMax.X) / 2, Y: (comboMin.Y + comboMax.Y) / 2}
+	io.SetMousePosition(center)
+	frame()
+	clickAt(io, center, frame)
+	frame()
+
+	assert.True(t, isOpen, "clicking the centered combo should open it")
+	assert.InDelta(t, comboMin.X, popupPos.X, 1, "the dropdown should be opened under the combo")
+	assert.InDelta(t, comboMax.Y, popupPos.Y, 1, "the dropdown should be opened under the combo")
+	assert.GreaterOrEqual(t, popupW, comboMax.X-comboMin.X, "the dropdown should be at least as wide as the combo")
+}
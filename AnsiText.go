@@ -0,0 +1,235 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/imgui-go"
+)
+
+// ansiColors is a standard 16-colors palette (8 normal colors followed by 8 bright).
+var ansiColors = [16]color.RGBA{
+	{0, 0, 0, 255},
+	{205, 49, 49, 255},
+	{13, 188, 121, 255},
+	{229, 229, 16, 255},
+	{36, 114, 200, 255},
+	{188, 63, 188, 255},
+	{17, 168, 205, 255},
+	{229, 229, 229, 255},
+	{102, 102, 102, 255},
+	{241, 76, 76, 255},
+	{35, 209, 139, 255},
+	{245, 245, 67, 255},
+	{59, 142, 234, 255},
+	{214, 112, 214, 255},
+	{41, 184, 219, 255},
+	{255, 255, 255, 255},
+}
+
+// ansiRun is a part of text with the same style.
+// nil colors mean default colors.
+type ansiRun struct {
+	text string
+	fg   color.Color
+	bg   color.Color
+	bold bool
+}
+
+type ansiStyle struct {
+	fg, bg color.Color
+	bold   bool
+}
+
+// applySGR applies "Select Graphic Rendition" parameters (e.g. "1;31") to the style.
+// Unsupported parameters are ignored.
+func (s *ansiStyle) applySGR(params string) {
+	if params == "" {
+		*s = ansiStyle{}
+		return
+	}
+
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case code == 0:
+			*s = ansiStyle{}
+		case code == 1:
+			s.bold = true
+		case code == 22:
+			s.bold = false
+		case code >= 30 && code <= 37:
+			s.fg = ansiColors[code-30]
+		case code == 39:
+			s.fg = nil
+		case code >= 40 && code <= 47:
+			s.bg = ansiColors[code-40]
+		case code == 49:
+			s.bg = nil
+		case code >= 90 && code <= 97:
+			s.fg = ansiColors[code-90+8]
+		case code >= 100 && code <= 107:
+			s.bg = ansiColors[code-100+8]
+		case code == 38 || code == 48:
+			// extended colors (38;5;n or 38;2;r;g;b) are not supported - skip its arguments
+			if i+1 < len(codes) {
+				switch codes[i+1] {
+				case "5":
+					i += 2
+				case "2":
+					i += 4
+				}
+			}
+		}
+	}
+}
+
+// parseAnsi splits text into runs of the same style interpreting
+// ANSI escape sequences. Unsupported sequences are stripped.
+func parseAnsi(text string) (runs []ansiRun) {
+	var (
+		style   ansiStyle
+		current strings.Builder
+	)
+
+	flush := func() {
+		if current.Len() == 0 {
+			return
+		}
+
+		runs = append(runs, ansiRun{
+			text: current.String(),
+			fg:   style.fg,
+			bg:   style.bg,
+			bold: style.bold,
+		})
+
+		current.Reset()
+	}
+
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\x1b' {
+			current.WriteByte(text[i])
+			continue
+		}
+
+		// not a CSI sequence - strip ESC, intermediate bytes and the final byte
+		if i+1 >= len(text) || text[i+1] != '[' {
+			i++
+			for i < len(text) && text[i] >= 0x20 && text[i] <= 0x2f {
+				i++
+			}
+
+			continue
+		}
+
+		// find final byte of the CSI sequence
+		end := i + 2
+		for end < len(text) && (text[end] < 0x40 || text[end] > 0x7e) {
+			end++
+		}
+
+		if end >= len(text) {
+			break
+		}
+
+		if text[end] == 'm' {
+			flush()
+			style.applySGR(text[i+2 : end])
+		}
+
+		i = end
+	}
+
+	flush()
+
+	return runs
+}
+
+var _ Widget = &AnsiTextWidget{}
+
+// AnsiTextWidget displays a text colored using ANSI escape codes
+// (e.g. output of a terminal program).
+// Supported are standard 16 foreground/background colors, bold and reset.
+// Other escape sequences are stripped.
+type AnsiTextWidget struct {
+	runs []ansiRun
+}
+
+// AnsiText creates a new AnsiTextWidget.
+func AnsiText(text string) *AnsiTextWidget {
+	return &AnsiTextWidget{
+		runs: parseAnsi(tStr(text)),
+	}
+}
+
+// Build implements Widget interface.
+func (a *AnsiTextWidget) Build() {
+	imgui.BeginGroup()
+	defer imgui.EndGroup()
+
+	isLineStart := true
+
+	for _, run := range a.runs {
+		lines := strings.Split(run.text, "\n")
+		for i, line := range lines {
+			if i > 0 {
+				// finish previous line
+				if isLineStart {
+					imgui.Text("")
+				}
+
+				isLineStart = true
+			}
+
+			if line == "" {
+				continue
+			}
+
+			if !isLineStart {
+				imgui.SameLineV(0, 0)
+			}
+
+			a.buildRun(run, line)
+
+			isLineStart = false
+		}
+	}
+}
+
+func (a *AnsiTextWidget) buildRun(run ansiRun, text string) {
+	pos := imgui.CursorScreenPos()
+	canvas := GetCanvas()
+
+	if run.bg != nil {
+		size := imgui.CalcTextSize(text, false, -1)
+		canvas.AddRectFilled(
+			image.Pt(int(pos.X), int(pos.Y)),
+			image.Pt(int(pos.X+size.X), int(pos.Y+size.Y)),
+			run.bg,
+			0,
+			DrawFlagsRoundCornersNone,
+		)
+	}
+
+	fg := Vec4ToColor(imgui.CurrentStyle().GetColor(imgui.StyleColorText))
+	if run.fg != nil {
+		fg = run.fg
+	}
+
+	imgui.PushStyleColor(imgui.StyleColorText, ToVec4Color(fg))
+	imgui.Text(text)
+	imgui.PopStyleColor()
+
+	// there is no bold font - draw the text once again, moved by one pixel
+	if run.bold {
+		canvas.AddText(image.Pt(int(pos.X)+1, int(pos.Y)), fg, text)
+	}
+}
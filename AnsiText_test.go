@@ -0,0 +1,62 @@
+package giu
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseAnsi(t *testing.T) {
+	red, green := ansiColors[1], ansiColors[2]
+	brightBlue := ansiColors[12]
+
+	tests := []struct {
+		name     string
+		text     string
+		expected []ansiRun
+	}{
+		{"plain", "hello", []ansiRun{{text: "hello"}}},
+		{"empty", "", nil},
+		{"foreground", "\x1b[31mred\x1b[0m plain", []ansiRun{
+			{text: "red", fg: red},
+			{text: " plain"},
+		}},
+		{"background and bold", "\x1b[1;42mtext", []ansiRun{
+			{text: "text", bg: green, bold: true},
+		}},
+		{"bright and default", "\x1b[94mblue\x1b[39mdefault", []ansiRun{
+			{text: "blue", fg: brightBlue},
+			{text: "default"},
+		}},
+		{"reset without params", "\x1b[31mred\x1b[mplain", []ansiRun{
+			{text: "red", fg: red},
+			{text: "plain"},
+		}},
+		{"unsupported stripped", "a\x1b[2Kb\x1b[38;5;200mc\x1b(B", []ansiRun{
+			{text: "ab"},
+			{text: "c"},
+		}},
+		{"unfinished sequence", "text\x1b[31", []ansiRun{{text: "text"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseAnsi(tc.text), "unexpected runs")
+		})
+	}
+}
+
+func Test_ansiStyle_applySGR(t *testing.T) {
+	s := ansiStyle{fg: ansiColors[1], bold: true}
+	s.applySGR("22;49")
+	assert.Equal(t, ansiStyle{fg: ansiColors[1]}, s, "unexpected style after disabling bold")
+
+	s.applySGR("0")
+	assert.Equal(t, ansiStyle{}, s, "style should be reset")
+
+	var c color.Color = ansiColors[7]
+
+	s.applySGR("37")
+	assert.Equal(t, c, s.fg, "unexpected foreground color")
+}
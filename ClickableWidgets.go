@@ -4,20 +4,25 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
 
 	"github.com/AllenDang/imgui-go"
 	"golang.org/x/image/colornames"
 )
 
-var _ Widget = &ButtonWidget{}
+var (
+	_ Widget     = &ButtonWidget{}
+	_ Measurable = &ButtonWidget{}
+)
 
 // ButtonWidget represents a ImGui button widget.
 type ButtonWidget struct {
-	id       string
-	width    float32
-	height   float32
-	disabled bool
-	onClick  func()
+	id        string
+	width     float32
+	height    float32
+	disabled  bool
+	fillWidth bool
+	onClick   func()
 }
 
 // Build implements Widget interface.
@@ -27,11 +32,35 @@ func (b *ButtonWidget) Build() {
 		defer imgui.EndDisabled()
 	}
 
-	if imgui.ButtonV(tStr(b.id), imgui.Vec2{X: b.width, Y: b.height}) && b.onClick != nil {
+	width := b.width
+	if b.fillWidth {
+		// in a Row, the cursor is already placed after previous items
+		width, _ = GetAvailableRegion()
+	}
+
+	if imgui.ButtonV(tStr(b.id), imgui.Vec2{X: width, Y: b.height}) && b.onClick != nil {
 		b.onClick()
 	}
 }
 
+// CalcWidth implements Measurable interface.
+func (b *ButtonWidget) CalcWidth() float32 {
+	availableW, _ := GetAvailableRegion()
+
+	switch {
+	case b.fillWidth:
+		return availableW
+	case b.width > 0:
+		return b.width
+	case b.width < 0:
+		return availableW + b.width
+	}
+
+	labelW, _ := CalcTextSizeV(tStr(b.id), true, -1)
+
+	return labelW + 2*imgui.CurrentStyle().FramePadding().X
+}
+
 // OnClick sets callback called when button is clicked
 // NOTE: to set double click, see EventHandler.go.
 func (b *ButtonWidget) OnClick(onClick func()) *ButtonWidget {
@@ -52,6 +81,14 @@ func (b *ButtonWidget) Size(width, height float32) *ButtonWidget {
 	return b
 }
 
+// FillWidth makes the button fill the remaining width of the row
+// (or the whole available width, if it is the only item in the row).
+// It overrides width set by Size.
+func (b *ButtonWidget) FillWidth(fill bool) *ButtonWidget {
+	b.fillWidth = fill
+	return b
+}
+
 // Button creates a new button widget.
 func Button(label string) *ButtonWidget {
 	return &ButtonWidget{
@@ -68,6 +105,128 @@ func Buttonf(format string, args ...interface{}) *ButtonWidget {
 	return Button(fmt.Sprintf(format, args...))
 }
 
+var _ Widget = &ButtonGroupWidget{}
+
+// ButtonGroupWidget lays out a set of buttons in a row
+// (e.g. a toolbar). Buttons can be sized uniformly and one of them
+// can be marked as pressed (toggle-style toolbars).
+type ButtonGroupWidget struct {
+	buttons  []*ButtonWidget
+	uniform  bool
+	selected *int32
+	onChange func()
+}
+
+// ButtonGroup creates a new ButtonGroupWidget.
+func ButtonGroup(buttons ...*ButtonWidget) *ButtonGroupWidget {
+	return &ButtonGroupWidget{
+		buttons:  buttons,
+		uniform:  false,
+		selected: nil,
+		onChange: nil,
+	}
+}
+
+// Uniform sets whether all the buttons should have the width
+// of the widest one. When disabled, buttons keep their natural width.
+func (bg *ButtonGroupWidget) Uniform(uniform bool) *ButtonGroupWidget {
+	bg.uniform = uniform
+	return bg
+}
+
+// Selected binds index of the pressed button. When set, clicking
+// a button updates `selected` and the pressed button is drawn
+// with StyleColorButtonActive.
+func (bg *ButtonGroupWidget) Selected(selected *int32) *ButtonGroupWidget {
+	bg.selected = selected
+	return bg
+}
+
+// OnChange sets callback called when selected button changes.
+func (bg *ButtonGroupWidget) OnChange(onChange func()) *ButtonGroupWidget {
+	bg.onChange = onChange
+	return bg
+}
+
+// calcUniformWidth returns the width of the widest button in the group.
+func (bg *ButtonGroupWidget) calcUniformWidth() (result float32) {
+	paddingX, _ := GetFramePadding()
+
+	for _, b := range bg.buttons {
+		if b == nil {
+			continue
+		}
+
+		w := b.width
+		if w <= 0 {
+			textW, _ := CalcTextSizeV(tStr(b.id), true, -1)
+			w = textW + 2*paddingX
+		}
+
+		if w > result {
+			result = w
+		}
+	}
+
+	return result
+}
+
+// Build implements Widget interface.
+func (bg *ButtonGroupWidget) Build() {
+	var width float32
+	if bg.uniform {
+		width = bg.calcUniformWidth()
+	}
+
+	isFirst := true
+
+	for i, b := range bg.buttons {
+		if b == nil {
+			continue
+		}
+
+		if !isFirst {
+			imgui.SameLine()
+		} else {
+			isFirst = false
+		}
+
+		// copy button to not affect user's widget
+		button := *b
+		if bg.uniform {
+			button.width = width
+		}
+
+		isPressed := bg.selected != nil && *bg.selected == int32(i)
+		if isPressed {
+			imgui.PushStyleColor(imgui.StyleColorButton, imgui.CurrentStyle().GetColor(imgui.StyleColorButtonActive))
+		}
+
+		if bg.selected != nil {
+			index := int32(i)
+			onClick := b.onClick
+			button.onClick = func() {
+				if *bg.selected != index {
+					*bg.selected = index
+					if bg.onChange != nil {
+						bg.onChange()
+					}
+				}
+
+				if onClick != nil {
+					onClick()
+				}
+			}
+		}
+
+		button.Build()
+
+		if isPressed {
+			imgui.PopStyleColor()
+		}
+	}
+}
+
 var _ Widget = &ArrowButtonWidget{}
 
 // ArrowButtonWidget represents a square button with an arrow.
@@ -105,7 +264,10 @@ func (b *ArrowButtonWidget) Build() {
 	}
 }
 
-var _ Widget = &SmallButtonWidget{}
+var (
+	_ Widget     = &SmallButtonWidget{}
+	_ Measurable = &SmallButtonWidget{}
+)
 
 type SmallButtonWidget struct {
 	id      string
@@ -128,6 +290,13 @@ func SmallButtonf(format string, args ...interface{}) *SmallButtonWidget {
 	return SmallButton(fmt.Sprintf(format, args...))
 }
 
+// CalcWidth implements Measurable interface.
+func (b *SmallButtonWidget) CalcWidth() float32 {
+	labelW, _ := CalcTextSizeV(tStr(b.id), true, -1)
+
+	return labelW + 2*imgui.CurrentStyle().FramePadding().X
+}
+
 // Build implements Widget interface.
 func (b *SmallButtonWidget) Build() {
 	if imgui.SmallButton(tStr(b.id)) && b.onClick != nil {
@@ -181,8 +350,8 @@ type ImageButtonWidget struct {
 	texture      *Texture
 	width        float32
 	height       float32
-	uv0          image.Point
-	uv1          image.Point
+	uv0          imgui.Vec2
+	uv1          imgui.Vec2
 	framePadding int
 	bgColor      color.Color
 	tintColor    color.Color
@@ -198,7 +367,7 @@ func (b *ImageButtonWidget) Build() {
 	if imgui.ImageButtonV(
 		b.texture.id,
 		imgui.Vec2{X: b.width, Y: b.height},
-		ToVec2(b.uv0), ToVec2(b.uv1),
+		b.uv0, b.uv1,
 		b.framePadding, ToVec4Color(b.bgColor),
 		ToVec4Color(b.tintColor),
 	) && b.onClick != nil {
@@ -217,7 +386,14 @@ func (b *ImageButtonWidget) OnClick(onClick func()) *ImageButtonWidget {
 }
 
 func (b *ImageButtonWidget) UV(uv0, uv1 image.Point) *ImageButtonWidget {
-	b.uv0, b.uv1 = uv0, uv1
+	b.uv0, b.uv1 = ToVec2(uv0), ToVec2(uv1)
+	return b
+}
+
+// UVf sets texture coordinates (in range 0-1) of the displayed part
+// of the texture (e.g. an icon of a sprite sheet).
+func (b *ImageButtonWidget) UVf(u0, v0, u1, v1 float32) *ImageButtonWidget {
+	b.uv0, b.uv1 = imgui.Vec2{X: u0, Y: v0}, imgui.Vec2{X: u1, Y: v1}
 	return b
 }
 
@@ -241,8 +417,8 @@ func ImageButton(texture *Texture) *ImageButtonWidget {
 		texture:      texture,
 		width:        50,
 		height:       50,
-		uv0:          image.Point{X: 0, Y: 0},
-		uv1:          image.Point{X: 1, Y: 1},
+		uv0:          imgui.Vec2{X: 0, Y: 0},
+		uv1:          imgui.Vec2{X: 1, Y: 1},
 		framePadding: -1,
 		bgColor:      colornames.Black,
 		tintColor:    colornames.White,
@@ -281,6 +457,12 @@ func (b *ImageButtonWithRgbaWidget) UV(uv0, uv1 image.Point) *ImageButtonWithRgb
 	return b
 }
 
+// UVf sets texture coordinates (see (*ImageButtonWidget).UVf).
+func (b *ImageButtonWithRgbaWidget) UVf(u0, v0, u1, v1 float32) *ImageButtonWithRgbaWidget {
+	b.ImageButtonWidget.UVf(u0, v0, u1, v1)
+	return b
+}
+
 func (b *ImageButtonWithRgbaWidget) BgColor(bgColor color.Color) *ImageButtonWithRgbaWidget {
 	b.ImageButtonWidget.BgColor(bgColor)
 	return b
@@ -314,7 +496,10 @@ func (b *ImageButtonWithRgbaWidget) Build() {
 	b.ImageButtonWidget.Build()
 }
 
-var _ Widget = &CheckboxWidget{}
+var (
+	_ Widget     = &CheckboxWidget{}
+	_ Measurable = &CheckboxWidget{}
+)
 
 // CheckboxWidget adds a checkbox.
 type CheckboxWidget struct {
@@ -330,6 +515,16 @@ func (c *CheckboxWidget) Build() {
 	}
 }
 
+// CalcWidth implements Measurable interface.
+func (c *CheckboxWidget) CalcWidth() float32 {
+	w := frameHeight()
+	if labelW, _ := CalcTextSizeV(tStr(c.text), true, -1); labelW > 0 {
+		w += imgui.CurrentStyle().ItemInnerSpacing().X + labelW
+	}
+
+	return w
+}
+
 // OnChange adds callback called when checkbox's state was changed.
 func (c *CheckboxWidget) OnChange(onChange func()) *CheckboxWidget {
 	c.onChange = onChange
@@ -345,6 +540,77 @@ func Checkbox(text string, selected *bool) *CheckboxWidget {
 	}
 }
 
+// CheckState represents a state of TriStateCheckbox.
+type CheckState byte
+
+// check states.
+const (
+	CheckStateUnchecked CheckState = iota
+	CheckStateChecked
+	// CheckStateIndeterminate could only be set programmatically
+	// (e.g. for "select all" checkbox, when only some items are selected).
+	CheckStateIndeterminate
+)
+
+var _ Widget = &TriStateCheckboxWidget{}
+
+// TriStateCheckboxWidget is a checkbox with an additional indeterminate state.
+// Clicking it switches between checked and unchecked states.
+type TriStateCheckboxWidget struct {
+	text     string
+	state    *CheckState
+	onChange func(CheckState)
+}
+
+// TriStateCheckbox creates a new TriStateCheckboxWidget.
+func TriStateCheckbox(text string, state *CheckState) *TriStateCheckboxWidget {
+	return &TriStateCheckboxWidget{
+		text:     GenAutoID(text),
+		state:    state,
+		onChange: nil,
+	}
+}
+
+// OnChange adds callback called when user clicks the checkbox.
+func (c *TriStateCheckboxWidget) OnChange(onChange func(CheckState)) *TriStateCheckboxWidget {
+	c.onChange = onChange
+	return c
+}
+
+// Build implements Widget interface.
+func (c *TriStateCheckboxWidget) Build() {
+	checked := *c.state == CheckStateChecked
+	if imgui.Checkbox(tStr(c.text), &checked) {
+		*c.state = CheckStateUnchecked
+		if checked {
+			*c.state = CheckStateChecked
+		}
+
+		if c.onChange != nil {
+			c.onChange(*c.state)
+		}
+	}
+
+	if *c.state != CheckStateIndeterminate {
+		return
+	}
+
+	// draw a dash inside of the (square) check box
+	itemMin, itemMax := imgui.GetItemRectMin(), imgui.GetItemRectMax()
+	squareSize := itemMax.Y - itemMin.Y
+	pad := float32(math.Max(1, math.Floor(float64(squareSize)/3.6)))
+	thickness := float32(math.Max(1, math.Floor(float64(squareSize)/6)))
+	centerY := itemMin.Y + squareSize/2
+
+	GetCanvas().AddRectFilled(
+		image.Pt(int(itemMin.X+pad), int(centerY-thickness/2)),
+		image.Pt(int(itemMin.X+squareSize-pad), int(centerY+thickness/2)),
+		Vec4ToRGBA(imgui.CurrentStyle().GetColor(imgui.StyleColorCheckMark)),
+		0,
+		DrawFlagsRoundCornersNone,
+	)
+}
+
 var _ Widget = &RadioButtonWidget{}
 
 type RadioButtonWidget struct {
@@ -373,16 +639,20 @@ func RadioButton(text string, active bool) *RadioButtonWidget {
 	}
 }
 
-var _ Widget = &SelectableWidget{}
+var (
+	_ Widget     = &SelectableWidget{}
+	_ Measurable = &SelectableWidget{}
+)
 
 type SelectableWidget struct {
-	label    string
-	selected bool
-	flags    SelectableFlags
-	width    float32
-	height   float32
-	onClick  func()
-	onDClick func()
+	label       string
+	selected    bool
+	selectedPtr *bool
+	flags       SelectableFlags
+	width       float32
+	height      float32
+	onClick     func()
+	onDClick    func()
 }
 
 func Selectable(label string) *SelectableWidget {
@@ -405,6 +675,13 @@ func (s *SelectableWidget) Selected(selected bool) *SelectableWidget {
 	return s
 }
 
+// SelectedPtr binds selectable's state to selected: it is toggled
+// when user clicks the selectable.
+func (s *SelectableWidget) SelectedPtr(selected *bool) *SelectableWidget {
+	s.selectedPtr = selected
+	return s
+}
+
 func (s *SelectableWidget) Flags(flags SelectableFlags) *SelectableWidget {
 	s.flags = flags
 	return s
@@ -430,30 +707,82 @@ func (s *SelectableWidget) OnDClick(onDClick func()) *SelectableWidget {
 // Build implements Widget interface.
 func (s *SelectableWidget) Build() {
 	// If onDClick is set, check flags and set related flag when necessary
-	if s.onDClick != nil && s.flags&SelectableFlagsAllowDoubleClick != 0 {
+	if s.onDClick != nil && s.flags&SelectableFlagsAllowDoubleClick == 0 {
 		s.flags |= SelectableFlagsAllowDoubleClick
 	}
 
-	if imgui.SelectableV(tStr(s.label), s.selected, int(s.flags), imgui.Vec2{X: s.width, Y: s.height}) && s.onClick != nil {
+	selected := s.selected
+	if s.selectedPtr != nil {
+		selected = *s.selectedPtr
+	}
+
+	clicked := imgui.SelectableV(tStr(s.label), selected, int(s.flags), imgui.Vec2{X: s.width, Y: s.height})
+
+	if clicked && s.selectedPtr != nil {
+		*s.selectedPtr = !*s.selectedPtr
+	}
+
+	if clicked && s.onClick != nil {
 		s.onClick()
 	}
 
-	if s.onDClick != nil && IsItemActive() && IsMouseDoubleClicked(MouseButtonLeft) {
+	if s.onDClick != nil && IsItemHovered() && IsMouseDoubleClicked(MouseButtonLeft) {
 		s.onDClick()
 	}
 }
 
+// CalcWidth implements Measurable interface. Unless the size is set explicitly,
+// it is a width of its label (selectables span the whole available width by default).
+// Like the selectable's rect, it includes the item spacing imgui extends its box by.
+func (s *SelectableWidget) CalcWidth() float32 {
+	w := s.calcSize()
+
+	if s.flags&SelectableFlagsSpanAllColumns == 0 {
+		spacingW, _ := GetItemSpacing()
+		w += spacingW
+	}
+
+	return w
+}
+
+// calcSize returns the selectable's size (without the item spacing):
+// the explicitly set width or a width of its label.
+func (s *SelectableWidget) calcSize() float32 {
+	if s.width > 0 {
+		return s.width
+	}
+
+	w, _ := CalcTextSizeV(tStr(s.label), true, -1)
+
+	return w
+}
+
+var _ Disposable = &treeNodeState{}
+
+type treeNodeState struct {
+	isOpen bool
+}
+
+// Dispose implements Disposable interface.
+func (s *treeNodeState) Dispose() {
+	// noop
+}
+
 var _ Widget = &TreeNodeWidget{}
 
 type TreeNodeWidget struct {
+	id           string
 	label        string
 	flags        TreeNodeFlags
 	layout       Layout
 	eventHandler func()
+	childrenFunc func() Layout
+	onExpand     func()
 }
 
 func TreeNode(label string) *TreeNodeWidget {
 	return &TreeNodeWidget{
+		id:           GenAutoID("TreeNode"),
 		label:        tStr(label),
 		flags:        0,
 		layout:       nil,
@@ -465,11 +794,37 @@ func TreeNodef(format string, args ...interface{}) *TreeNodeWidget {
 	return TreeNode(fmt.Sprintf(format, args...))
 }
 
+// ID allows to manually set widget's id (used for its state, see OnExpand).
+func (t *TreeNodeWidget) ID(id string) *TreeNodeWidget {
+	t.id = id
+	return t
+}
+
 func (t *TreeNodeWidget) Flags(flags TreeNodeFlags) *TreeNodeWidget {
 	t.flags = flags
 	return t
 }
 
+// Leaf makes the node a leaf (no arrow, can't be collapsed).
+func (t *TreeNodeWidget) Leaf(isLeaf bool) *TreeNodeWidget {
+	return t.setFlag(TreeNodeFlagsLeaf, isLeaf)
+}
+
+// Selected draws the node highlighted as selected.
+func (t *TreeNodeWidget) Selected(isSelected bool) *TreeNodeWidget {
+	return t.setFlag(TreeNodeFlagsSelected, isSelected)
+}
+
+func (t *TreeNodeWidget) setFlag(flag TreeNodeFlags, value bool) *TreeNodeWidget {
+	if value {
+		t.flags |= flag
+	} else {
+		t.flags &^= flag
+	}
+
+	return t
+}
+
 // Event create TreeNode with eventHandler
 // You could detect events (e.g. IsItemClicked IsMouseDoubleClicked etc...) and handle them for TreeNode inside eventHandler.
 func (t *TreeNodeWidget) Event(handler func()) *TreeNodeWidget {
@@ -477,23 +832,164 @@ func (t *TreeNodeWidget) Event(handler func()) *TreeNodeWidget {
 	return t
 }
 
+// OnExpand sets callback called when the node gets opened
+// (or when it is shown opened for the first time).
+func (t *TreeNodeWidget) OnExpand(onExpand func()) *TreeNodeWidget {
+	t.onExpand = onExpand
+	return t
+}
+
 func (t *TreeNodeWidget) Layout(widgets ...Widget) *TreeNodeWidget {
 	t.layout = Layout(widgets)
 	return t
 }
 
+// To sets a function creating node's children. Unlike Layout,
+// the function is called only when the node is open,
+// so children of collapsed nodes of large trees aren't created at all.
+func (t *TreeNodeWidget) To(childrenFunc func() Layout) *TreeNodeWidget {
+	t.childrenFunc = childrenFunc
+	return t
+}
+
 // Build implements Widget interface.
 func (t *TreeNodeWidget) Build() {
+	// with OnExpand, the open state is kept in the widget's state,
+	// so that the callback is called only when the node gets opened
+	var state *treeNodeState
+	if t.onExpand != nil {
+		if s := Context.GetState(t.id); s == nil {
+			state = &treeNodeState{}
+			Context.SetState(t.id, state)
+		} else {
+			var isOk bool
+			state, isOk = s.(*treeNodeState)
+			Assert(isOk, "TreeNodeWidget", "Build", "wrong state type recovered.")
+			imgui.SetNextItemOpen(state.isOpen, imgui.ConditionAlways)
+		}
+	}
+
 	open := imgui.TreeNodeV(t.label, int(t.flags))
 
 	if t.eventHandler != nil {
 		t.eventHandler()
 	}
 
+	if state != nil {
+		if open && !state.isOpen {
+			t.onExpand()
+		}
+
+		state.isOpen = open
+	}
+
 	if open {
 		t.layout.Build()
+
+		if t.childrenFunc != nil {
+			t.childrenFunc().Build()
+		}
+
 		if (t.flags & imgui.TreeNodeFlagsNoTreePushOnOpen) == 0 {
 			imgui.TreePop()
 		}
 	}
 }
+
+// AccordionSection represents a section of AccordionWidget.
+type AccordionSection struct {
+	Header string
+	Layout Layout
+}
+
+var _ Disposable = &accordionState{}
+
+type accordionState struct {
+	// index of currently open section (-1 if all sections are closed)
+	open int
+}
+
+// Dispose implements Disposable interface.
+func (s *accordionState) Dispose() {
+	// noop
+}
+
+var _ Widget = &AccordionWidget{}
+
+// AccordionWidget is a list of collapsing headers, where only one section
+// could be open at a time (opening a section closes the others).
+type AccordionWidget struct {
+	id          string
+	sections    []AccordionSection
+	defaultOpen int
+	onChange    func(openIndex int)
+}
+
+// Accordion creates a new AccordionWidget.
+func Accordion(sections ...AccordionSection) *AccordionWidget {
+	return &AccordionWidget{
+		id:          GenAutoID("Accordion"),
+		sections:    sections,
+		defaultOpen: -1,
+		onChange:    nil,
+	}
+}
+
+// ID allows to manually set widget's id.
+func (a *AccordionWidget) ID(id string) *AccordionWidget {
+	a.id = id
+	return a
+}
+
+// DefaultOpen sets index of section open when the accordion is displayed first time.
+func (a *AccordionWidget) DefaultOpen(index int) *AccordionWidget {
+	a.defaultOpen = index
+	return a
+}
+
+// OnChange sets callback called when user opens a section
+// (or closes it: openIndex is -1 then).
+func (a *AccordionWidget) OnChange(onChange func(openIndex int)) *AccordionWidget {
+	a.onChange = onChange
+	return a
+}
+
+// Build implements Widget interface.
+func (a *AccordionWidget) Build() {
+	var state *accordionState
+	if s := Context.GetState(a.id); s == nil {
+		state = &accordionState{open: a.defaultOpen}
+		Context.SetState(a.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*accordionState)
+		Assert(isOk, "AccordionWidget", "Build", "unexpected state recovered")
+	}
+
+	newOpen := state.open
+
+	for idx, section := range a.sections {
+		shouldBeOpen := idx == state.open
+		imgui.SetNextItemOpen(shouldBeOpen, imgui.ConditionAlways)
+
+		open := imgui.TreeNodeV(fmt.Sprintf("%s##%s%d", tStr(section.Header), a.id, idx), int(TreeNodeFlagsCollapsingHeader))
+		if open != shouldBeOpen {
+			// clicked by user
+			newOpen = -1
+			if open {
+				newOpen = idx
+			}
+		}
+
+		if open {
+			section.Layout.Build()
+		}
+	}
+
+	if newOpen != state.open {
+		state.open = newOpen
+		if a.onChange != nil {
+			a.onChange(newOpen)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package giu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TreeNodeWidget_StateKey(t *testing.T) {
+	io := newTestContext(t)
+
+	var value string
+
+	expanded := [2]int{}
+
+	var nodeMin, nodeMax imgui.Vec2
+
+	// like MasterWindow, unused states are cleaned after each frame
+	frame := func() {
+		Context.invalidAllState()
+		imgui.NewFrame()
+		imgui.Begin("tree node state")
+
+		// the input's state is stored under the same label
+		InputText(&value).Label("shared label").Build()
+
+		// nodes with the same label in different id scopes
+		for idx := range expanded {
+			idx := idx
+
+			imgui.PushID(fmt.Sprint(idx))
+			TreeNode("shared label").Flags(TreeNodeFlagsDefaultOpen).OnExpand(func() { expanded[idx]++ }).Build()
+			imgui.PopID()
+
+			if idx == 0 {
+				nodeMin, nodeMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+			}
+		}
+
+		imgui.End()
+		imgui.Render()
+		Context.cleanState()
+	}
+
+	assert.NotPanics(t, func() {
+		for n := 0; n < 5; n++ {
+			frame()
+		}
+	}, "tree node's state collides with the input's one")
+
+	assert.Equal(t, [2]int{1, 1}, expanded, "OnExpand should be called once for each node")
+
+	// collapse the first node and open it again
+	nodePos := imgui.Vec2{X: nodeMin.X + 5, Y: (nodeMin.Y + nodeMax.Y) / 2}
+	clickAt(io, nodePos, frame)
+	frame()
+	assert.Equal(t, [2]int{1, 1}, expanded, "OnExpand called when the node was collapsed")
+
+	clickAt(io, nodePos, frame)
+	frame()
+	assert.Equal(t, [2]int{2, 1}, expanded, "OnExpand should be called when the node is opened again")
+}
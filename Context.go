@@ -1,6 +1,7 @@
 package giu
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/AllenDang/imgui-go"
@@ -30,6 +31,9 @@ type context struct {
 	// States will used by custom widget to store data
 	state sync.Map
 
+	// id scopes (see IndexedRangeBuilder) prepended to states' ids
+	idScope []string
+
 	InputHandler InputHandler
 }
 
@@ -69,12 +73,33 @@ func (c *context) cleanState() {
 	c.widgetIndexCounter = 0
 }
 
+func (c *context) pushIDScope(id string) {
+	c.idScope = append(c.idScope, id)
+}
+
+func (c *context) popIDScope() {
+	if len(c.idScope) > 0 {
+		c.idScope = c.idScope[:len(c.idScope)-1]
+	}
+}
+
+// stateKey returns id of state in the current id scope.
+// The scope is set only while the widgets are built, so the same id
+// gives a different key outside of Build.
+func (c *context) stateKey(id string) string {
+	if len(c.idScope) == 0 {
+		return id
+	}
+
+	return strings.Join(c.idScope, "/") + "/" + id
+}
+
 func (c *context) SetState(id string, data Disposable) {
-	c.state.Store(id, &state{valid: true, data: data})
+	c.state.Store(c.stateKey(id), &state{valid: true, data: data})
 }
 
 func (c *context) GetState(id string) interface{} {
-	if v, ok := c.state.Load(id); ok {
+	if v, ok := c.state.Load(c.stateKey(id)); ok {
 		if s, ok := v.(*state); ok {
 			s.valid = true
 			return s.data
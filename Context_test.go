@@ -63,3 +63,28 @@ func Test_GetWidgetIndex(t *testing.T) {
 		assert.Equal(t, i, ctx.GetWidgetIndex(), "widget index wasn't increased")
 	}
 }
+
+func Test_idScope(t *testing.T) {
+	ctx := context{}
+
+	ctx.pushIDScope("rows/0")
+	first := &inputTextState{}
+	ctx.SetState("##name", first)
+	ctx.popIDScope()
+
+	ctx.pushIDScope("rows/1")
+	assert.Nil(t, ctx.GetState("##name"), "state of the first row shouldn't be visible in the second one")
+	second := &inputTextState{}
+	ctx.SetState("##name", second)
+	ctx.popIDScope()
+
+	ctx.pushIDScope("rows/0")
+	assert.Same(t, first, ctx.GetState("##name"))
+	ctx.popIDScope()
+
+	ctx.pushIDScope("rows/1")
+	assert.Same(t, second, ctx.GetState("##name"))
+	ctx.popIDScope()
+
+	assert.Nil(t, ctx.GetState("##name"), "state outside of the scopes shouldn't be set")
+}
@@ -0,0 +1,218 @@
+package giu
+
+import "fmt"
+
+// DockPane is a named pane of DockLiteWidget.
+type DockPane struct {
+	Name   string
+	Layout Layout
+	// initial size of the pane (ignored for the last pane, which fills the remaining space)
+	Size float32
+	// the pane can't be resized below MinSize
+	MinSize float32
+}
+
+var _ Disposable = &dockLiteState{}
+
+type dockLiteState struct {
+	// sizes of all panes except of the last one
+	sizes []float32
+	// splitters' deltas
+	deltas []float32
+}
+
+// Dispose implements Disposable interface.
+func (s *dockLiteState) Dispose() {
+	// noop
+}
+
+// resizeDockPanes moves the splitter between panes i and i+1 by delta respecting
+// panes' minimal sizes. sizes holds sizes of all panes except of the last one,
+// which fills the rest of total.
+func resizeDockPanes(sizes, minSizes []float32, total float32, i int, delta float32) {
+	next := total
+	if i+1 < len(sizes) {
+		next = sizes[i+1]
+	} else {
+		for _, s := range sizes {
+			next -= s
+		}
+	}
+
+	if sizes[i]+delta < minSizes[i] {
+		delta = minSizes[i] - sizes[i]
+	}
+
+	if next-delta < minSizes[i+1] {
+		delta = next - minSizes[i+1]
+	}
+
+	sizes[i] += delta
+	if i+1 < len(sizes) {
+		sizes[i+1] -= delta
+	}
+}
+
+var _ Widget = &DockLiteWidget{}
+
+// DockLiteWidget arranges named panes separated by splitters
+// (see also SplitLayout). A pane's layout could be another DockLite
+// to create nested layouts.
+// Pane sizes are kept in giu's state by the widget's id; to keep them
+// between application runs, save them in OnResize and restore by Sizes.
+type DockLiteWidget struct {
+	id         string
+	direction  SplitDirection
+	panes      []DockPane
+	savedSizes map[string]float32
+	onResize   func(sizes map[string]float32)
+}
+
+// DockLite creates a new DockLiteWidget. The id should be stable
+// (e.g. "main-layout") to keep the pane sizes.
+func DockLite(id string, direction SplitDirection, panes ...DockPane) *DockLiteWidget {
+	return &DockLiteWidget{
+		id:        id,
+		direction: direction,
+		panes:     panes,
+	}
+}
+
+// Sizes restores pane sizes (by pane names) e.g. saved in a previous run.
+// It is used only when the widget is built the first time.
+func (d *DockLiteWidget) Sizes(sizes map[string]float32) *DockLiteWidget {
+	d.savedSizes = sizes
+	return d
+}
+
+// OnResize sets callback called when user resizes the panes.
+// It receives sizes of all but the last pane (by pane names).
+func (d *DockLiteWidget) OnResize(onResize func(sizes map[string]float32)) *DockLiteWidget {
+	d.onResize = onResize
+	return d
+}
+
+func (d *DockLiteWidget) getState() (state *dockLiteState) {
+	if s := Context.GetState(d.id); s == nil {
+		state = &dockLiteState{}
+
+		for _, pane := range d.panes[:len(d.panes)-1] {
+			size := pane.Size
+			if saved, ok := d.savedSizes[pane.Name]; ok {
+				size = saved
+			}
+
+			state.sizes = append(state.sizes, size)
+		}
+
+		state.deltas = make([]float32, len(state.sizes))
+
+		Context.SetState(d.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*dockLiteState)
+		Assert(isOk, "DockLiteWidget", "Build", "got unexpected type of widget's state")
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (d *DockLiteWidget) Build() {
+	if len(d.panes) == 0 {
+		return
+	}
+
+	state := d.getState()
+	Assert(len(state.sizes) == len(d.panes)-1, "DockLiteWidget", "Build", "number of panes can't be changed")
+
+	spacingX, spacingY := GetItemSpacing()
+	availableW, availableH := GetAvailableRegion()
+
+	total := availableW - spacingX*float32(len(state.sizes))
+	if d.direction == DirectionVertical {
+		total = availableH - spacingY*float32(len(state.sizes))
+	}
+
+	minSizes := make([]float32, len(d.panes))
+	for i, pane := range d.panes {
+		minSizes[i] = pane.MinSize
+	}
+
+	isResized := false
+
+	for i, delta := range state.deltas {
+		if delta != 0 {
+			resizeDockPanes(state.sizes, minSizes, total, i, delta)
+			isResized = true
+		}
+	}
+
+	if isResized && d.onResize != nil {
+		sizes := make(map[string]float32, len(state.sizes))
+		for i, size := range state.sizes {
+			sizes[d.panes[i].Name] = size
+		}
+
+		d.onResize(sizes)
+	}
+
+	var widgets []Widget
+
+	for i, pane := range d.panes {
+		width, height := float32(Auto), float32(Auto)
+		if i < len(state.sizes) {
+			if d.direction == DirectionVertical {
+				height = state.sizes[i]
+			} else {
+				width = state.sizes[i]
+			}
+		}
+
+		widgets = append(widgets, d.buildPane(pane, width, height, spacingX, spacingY))
+
+		if i == len(state.sizes) {
+			break
+		}
+
+		splitterID := fmt.Sprintf("%s##splitter%d", d.id, i)
+		if d.direction == DirectionVertical {
+			widgets = append(widgets, HSplitter(&state.deltas[i]).ID(splitterID).Size(0, spacingY))
+		} else {
+			widgets = append(widgets, VSplitter(&state.deltas[i]).ID(splitterID).Size(spacingX, 0))
+		}
+	}
+
+	PushItemSpacing(0, 0)
+
+	if d.direction == DirectionVertical {
+		Column(widgets...).Build()
+	} else {
+		Row(widgets...).Build()
+	}
+
+	PopStyle()
+}
+
+func (d *DockLiteWidget) buildPane(pane DockPane, width, height, spacingX, spacingY float32) Widget {
+	return Custom(func() {
+		// nested DockLite doesn't need another border
+		isNested := false
+		if len(pane.Layout) == 1 {
+			_, isNested = pane.Layout[0].(*DockLiteWidget)
+		}
+
+		Child().
+			Border(!isNested).
+			Size(width, height).
+			Layout(
+				Custom(func() {
+					// restore item spacing in the pane
+					PushItemSpacing(spacingX, spacingY)
+					pane.Layout.Build()
+					PopStyle()
+				}),
+			).
+			Build()
+	})
+}
@@ -0,0 +1,35 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_resizeDockPanes(t *testing.T) {
+	tests := []struct {
+		name     string
+		sizes    []float32
+		i        int
+		delta    float32
+		expected []float32
+	}{
+		{"grow first", []float32{100, 100}, 0, 20, []float32{120, 80}},
+		{"shrink first", []float32{100, 100}, 0, -20, []float32{80, 120}},
+		{"first min size", []float32{100, 100}, 0, -80, []float32{50, 150}},
+		{"next min size", []float32{100, 100}, 0, 80, []float32{150, 50}},
+		{"last pane min size", []float32{100, 100}, 1, 150, []float32{100, 150}},
+		{"grow last", []float32{100, 100}, 1, -30, []float32{100, 70}},
+	}
+
+	// total 300, each pane at least 50
+	minSizes := []float32{50, 50, 50}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sizes := append([]float32{}, tc.sizes...)
+			resizeDockPanes(sizes, minSizes, 300, tc.i, tc.delta)
+			assert.Equal(t, tc.expected, sizes)
+		})
+	}
+}
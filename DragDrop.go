@@ -0,0 +1,109 @@
+package giu
+
+import "github.com/AllenDang/imgui-go"
+
+// dragDropPayload is data of the currently dragged DragDropSource.
+// imgui-go's payloads can hold only an int, so the data is kept here
+// (there could be only one drag in progress at the time).
+var dragDropPayload []byte
+
+var _ Widget = &DragDropSourceWidget{}
+
+// DragDropSourceWidget makes a widget draggable. While dragging,
+// the preview layout is shown next to the mouse cursor and the data can be
+// dropped onto a DragDropTarget of the same payload type.
+type DragDropSourceWidget struct {
+	payloadType string
+	data        []byte
+	widget      Widget
+	preview     Layout
+}
+
+// DragDropSource creates a new DragDropSourceWidget.
+// payloadType is a user-defined type of data (e.g. "LIST_ITEM");
+// imgui's limit for its length is 32 characters.
+func DragDropSource(payloadType string, data []byte) *DragDropSourceWidget {
+	return &DragDropSourceWidget{
+		payloadType: payloadType,
+		data:        data,
+	}
+}
+
+// To sets the widget which can be dragged.
+// NOTE: the widget should be an item with an id (e.g. Button or Selectable);
+// for a layout, wrap it in a Child or use a Selectable as a handle.
+func (d *DragDropSourceWidget) To(widget Widget) *DragDropSourceWidget {
+	d.widget = widget
+	return d
+}
+
+// Preview sets layout shown next to the mouse cursor while dragging.
+func (d *DragDropSourceWidget) Preview(widgets ...Widget) *DragDropSourceWidget {
+	d.preview = widgets
+	return d
+}
+
+// Build implements Widget interface.
+func (d *DragDropSourceWidget) Build() {
+	if d.widget == nil {
+		return
+	}
+
+	d.widget.Build()
+
+	if !imgui.BeginDragDropSource() {
+		return
+	}
+
+	dragDropPayload = d.data
+	imgui.SetDragDropPayload(d.payloadType, 0)
+
+	d.preview.Build()
+
+	imgui.EndDragDropSource()
+}
+
+var _ Widget = &DragDropTargetWidget{}
+
+// DragDropTargetWidget allows to drop data of DragDropSource onto a widget.
+// Only payloads of the target's type are accepted; others aren't
+// highlighted and can't be dropped.
+type DragDropTargetWidget struct {
+	payloadType string
+	onDrop      func(data []byte)
+	widget      Widget
+}
+
+// DragDropTarget creates a new DragDropTargetWidget.
+// onDrop is called with data of the dropped source.
+func DragDropTarget(payloadType string, onDrop func(data []byte)) *DragDropTargetWidget {
+	return &DragDropTargetWidget{
+		payloadType: payloadType,
+		onDrop:      onDrop,
+	}
+}
+
+// To sets the widget the data can be dropped onto.
+func (d *DragDropTargetWidget) To(widget Widget) *DragDropTargetWidget {
+	d.widget = widget
+	return d
+}
+
+// Build implements Widget interface.
+func (d *DragDropTargetWidget) Build() {
+	if d.widget == nil {
+		return
+	}
+
+	d.widget.Build()
+
+	if !imgui.BeginDragDropTarget() {
+		return
+	}
+
+	if payload := imgui.AcceptDragDropPayload(d.payloadType); payload != 0 && d.onDrop != nil {
+		d.onDrop(dragDropPayload)
+	}
+
+	imgui.EndDragDropTarget()
+}
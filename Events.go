@@ -35,6 +35,31 @@ func IsKeyPressed(key Key) bool {
 	return imgui.IsKeyPressed(int(key))
 }
 
+// keysCount is a size of imgui's keys array.
+const keysCount = 512
+
+// isUserInputInFrame returns true if any key has been pressed,
+// mouse button clicked or mouse dragged in the current frame.
+func isUserInputInFrame() bool {
+	for key := 0; key < keysCount; key++ {
+		if imgui.IsKeyPressed(key) {
+			return true
+		}
+	}
+
+	for _, button := range []MouseButton{MouseButtonLeft, MouseButtonRight, MouseButtonMiddle} {
+		if IsMouseClicked(button) {
+			return true
+		}
+
+		if delta := Context.IO().GetMouseDelta(); IsMouseDown(button) && (delta.X != 0 || delta.Y != 0) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // IsKeyReleased returns true if key `key` is released.
 func IsKeyReleased(key Key) bool {
 	return imgui.IsKeyReleased(int(key))
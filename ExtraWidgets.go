@@ -3,6 +3,8 @@ package giu
 import (
 	"fmt"
 	"image"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/AllenDang/imgui-go"
@@ -348,6 +350,261 @@ func (c *ConditionWidget) Build() {
 	}
 }
 
+var _ Disposable = &memoState{}
+
+type memoState struct {
+	deps   []interface{}
+	widget Widget
+	// number of auto IDs generated while constructing the widget
+	autoIDs int
+}
+
+// Dispose implements Disposable interface.
+func (s *memoState) Dispose() {
+	s.widget = nil
+}
+
+// memoDepsEqual compares dependencies using ==.
+// Not comparable values (e.g. slices) are never considered equal.
+func memoDepsEqual(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] == nil || b[i] == nil {
+			if a[i] != b[i] {
+				return false
+			}
+
+			continue
+		}
+
+		if !reflect.TypeOf(a[i]).Comparable() || !reflect.TypeOf(b[i]).Comparable() || a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+var _ Widget = &MemoWidget{}
+
+// MemoWidget caches a widget and constructs it again only when
+// dependencies change. It is useful for sub-layouts which are expensive
+// to create (e.g. generated from large data).
+// NOTE: only construction is memoized - the cached widget is still built every frame.
+// The cached widget keeps auto IDs generated while it was constructed.
+type MemoWidget struct {
+	id      string
+	deps    []interface{}
+	builder func() Widget
+}
+
+// Memo creates a new MemoWidget. Dependencies are compared using ==
+// (pointers are compared, not values they point to; not comparable values
+// like slices or maps always cause the widget to be constructed again).
+func Memo(deps ...interface{}) *MemoWidget {
+	return &MemoWidget{
+		id:   GenAutoID("Memo"),
+		deps: deps,
+	}
+}
+
+// ID allows to manually set widget's id (recommended if Memo is built conditionally).
+func (m *MemoWidget) ID(id string) *MemoWidget {
+	m.id = id
+	return m
+}
+
+// To sets a function constructing the widget.
+func (m *MemoWidget) To(builder func() Widget) *MemoWidget {
+	m.builder = builder
+	return m
+}
+
+// Build implements Widget interface.
+func (m *MemoWidget) Build() {
+	if m.builder == nil {
+		return
+	}
+
+	var state *memoState
+	if s := Context.GetState(m.id); s == nil {
+		state = &memoState{}
+		Context.SetState(m.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*memoState)
+		Assert(isOk, "MemoWidget", "Build", "got unexpected type of widget's state")
+	}
+
+	if state.widget == nil || !memoDepsEqual(state.deps, m.deps) {
+		start := Context.widgetIndexCounter
+		state.widget = m.builder()
+		state.deps = m.deps
+		state.autoIDs = Context.widgetIndexCounter - start
+	} else {
+		// skip the auto IDs the builder would generate,
+		// so that IDs of widgets built after the Memo don't change
+		Context.widgetIndexCounter += state.autoIDs
+	}
+
+	if state.widget != nil {
+		state.widget.Build()
+	}
+}
+
+// pagerPages returns pages shown by PagerWidget: the first and the last page
+// and a window of pages around current; 0 stands for an ellipsis.
+func pagerPages(current, total int) (pages []int) {
+	// number of pages shown on each side of the current page
+	const siblings = 2
+
+	if total < 1 {
+		return nil
+	}
+
+	// add appends page after an ellipsis (or the single hidden page) if needed
+	add := func(page int) {
+		if n := len(pages); n > 0 {
+			last := pages[n-1]
+
+			switch {
+			case page <= last:
+				return
+			case page == last+2:
+				// a single hidden page is shown instead of an ellipsis
+				pages = append(pages, last+1)
+			case page > last+2:
+				pages = append(pages, 0)
+			}
+		}
+
+		pages = append(pages, page)
+	}
+
+	add(1)
+
+	from, to := current-siblings, current+siblings
+	if from < 1 {
+		from = 1
+	}
+
+	if to > total {
+		to = total
+	}
+
+	for page := from; page <= to; page++ {
+		add(page)
+	}
+
+	add(total)
+
+	return pages
+}
+
+var _ Widget = &PagerWidget{}
+
+// PagerWidget is a pagination control: buttons of the first, previous,
+// a few pages around the current one, next and the last page.
+type PagerWidget struct {
+	id          string
+	currentPage *int
+	totalPages  int
+	onChange    func(page int)
+}
+
+// Pager creates a new PagerWidget (pages are numbered from 1).
+func Pager(currentPage *int, totalPages int) *PagerWidget {
+	return &PagerWidget{
+		id:          GenAutoID("Pager"),
+		currentPage: currentPage,
+		totalPages:  totalPages,
+	}
+}
+
+// ID allows to manually set widget's id.
+func (p *PagerWidget) ID(id string) *PagerWidget {
+	p.id = id
+	return p
+}
+
+// OnChange sets callback called when user changes the page.
+func (p *PagerWidget) OnChange(onChange func(page int)) *PagerWidget {
+	p.onChange = onChange
+	return p
+}
+
+// Build implements Widget interface.
+func (p *PagerWidget) Build() {
+	if p.totalPages < 1 {
+		return
+	}
+
+	if *p.currentPage < 1 {
+		*p.currentPage = 1
+	} else if *p.currentPage > p.totalPages {
+		*p.currentPage = p.totalPages
+	}
+
+	current := *p.currentPage
+	newPage := current
+
+	imgui.PushID(p.id)
+	defer imgui.PopID()
+
+	navButton := func(label string, page int, isDisabled bool) {
+		imgui.BeginDisabled(isDisabled)
+
+		if imgui.Button(label) {
+			newPage = page
+		}
+
+		imgui.EndDisabled()
+		imgui.SameLine()
+	}
+
+	navButton("<<", 1, current == 1)
+	navButton("<", current-1, current == 1)
+
+	for _, page := range pagerPages(current, p.totalPages) {
+		if page == 0 {
+			imgui.Text("...")
+			imgui.SameLine()
+
+			continue
+		}
+
+		if page == current {
+			imgui.PushStyleColor(imgui.StyleColorButton, imgui.CurrentStyle().GetColor(imgui.StyleColorButtonActive))
+		}
+
+		if imgui.Button(fmt.Sprintf("%d", page)) {
+			newPage = page
+		}
+
+		if page == current {
+			imgui.PopStyleColor()
+		}
+
+		imgui.SameLine()
+	}
+
+	navButton(">", current+1, current == p.totalPages)
+	navButton(">>", p.totalPages, current == p.totalPages)
+	// finish the line started by the buttons
+	imgui.Dummy(imgui.Vec2{})
+
+	if newPage != current {
+		*p.currentPage = newPage
+
+		if p.onChange != nil {
+			p.onChange(newPage)
+		}
+	}
+}
+
 // RangeBuilder batch create widgets and render only which is visible.
 func RangeBuilder(id string, values []interface{}, builder func(int, interface{}) Widget) Layout {
 	var layout Layout
@@ -367,6 +624,39 @@ func RangeBuilder(id string, values []interface{}, builder func(int, interface{}
 	return layout
 }
 
+// IndexedRangeBuilder builds count widgets; each of them is built in its own
+// id scope (baseID and the index), so widgets with the same label/id (e.g. InputText("Name")
+// built in a loop) share neither imgui ids nor giu's state.
+// It is the recommended way to build lists of inputs.
+// NOTE: the scope is active only while the widgets are built, so state accessed
+// outside of Build (e.g. AutoSaveWidget.LastSaved called after the layout
+// is built) would be looked up outside of the scope, i.e. a different (new) state.
+func IndexedRangeBuilder(baseID string, count int, build func(i int) Widget) Layout {
+	layout := make(Layout, 0, count)
+
+	for i := 0; i < count; i++ {
+		widget := build(i)
+		if widget == nil {
+			continue
+		}
+
+		scopeID := fmt.Sprintf("%s/%d", baseID, i)
+
+		layout = append(layout, Custom(func() {
+			// the scopes are popped even if the widget panics (e.g. on a failed Assert)
+			imgui.PushID(scopeID)
+			defer imgui.PopID()
+
+			Context.pushIDScope(scopeID)
+			defer Context.popIDScope()
+
+			widget.Build()
+		}))
+	}
+
+	return layout
+}
+
 type ListBoxState struct {
 	selectedIndex int
 }
@@ -677,3 +967,646 @@ func (d *DatePickerWidget) calendarField(day int) Widget {
 		}
 	})
 }
+
+var _ Disposable = &codeInputState{}
+
+type codeInputState struct {
+	// characters of boxes (0 means empty box)
+	chars []rune
+	// index of box, which should be focused in the next frame (-1 if none)
+	focus int
+}
+
+// Dispose implements Disposable interface.
+func (s *codeInputState) Dispose() {
+	s.chars = nil
+}
+
+var _ Widget = &CodeInputWidget{}
+
+// CodeInputWidget is a segmented code input (e.g. for one-time passwords).
+// It displays a row of single-character boxes; the focus moves to the next box
+// as user types and to the previous one on backspace in an empty box.
+// Pasting a code into a box fills it and the following boxes.
+type CodeInputWidget struct {
+	id         string
+	value      *string
+	length     int
+	flags      InputTextFlags
+	onComplete func(code string)
+}
+
+// CodeInput creates a new CodeInputWidget with length boxes.
+func CodeInput(value *string, length int) *CodeInputWidget {
+	return &CodeInputWidget{
+		id:     GenAutoID("CodeInput"),
+		value:  value,
+		length: length,
+		flags:  InputTextFlagsCharsNoBlank,
+	}
+}
+
+// ID allows to manually set widget's id.
+func (c *CodeInputWidget) ID(id string) *CodeInputWidget {
+	c.id = id
+	return c
+}
+
+// Flags sets flags of the boxes (e.g. InputTextFlagsCharsDecimal for digits only).
+func (c *CodeInputWidget) Flags(flags InputTextFlags) *CodeInputWidget {
+	c.flags = flags
+	return c
+}
+
+// OnComplete sets callback called when all boxes get filled.
+func (c *CodeInputWidget) OnComplete(onComplete func(code string)) *CodeInputWidget {
+	c.onComplete = onComplete
+	return c
+}
+
+func (c *CodeInputWidget) getState() (state *codeInputState) {
+	if s := Context.GetState(c.id); s == nil {
+		state = &codeInputState{focus: -1}
+		Context.SetState(c.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*codeInputState)
+		Assert(isOk, "CodeInputWidget", "getState", "unexpected state recovered")
+	}
+
+	// value changed outside of the widget (or length changed)
+	if len(state.chars) != c.length || codeInputValue(state.chars) != *c.value {
+		state.chars = make([]rune, c.length)
+		fillCodeInput(state.chars, 0, []rune(*c.value))
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (c *CodeInputWidget) Build() {
+	if c.value == nil || c.length <= 0 {
+		return
+	}
+
+	state := c.getState()
+
+	imgui.PushID(c.id)
+	defer imgui.PopID()
+
+	boxWidth := imgui.FontSize() + 2*imgui.CurrentStyle().FramePadding().X
+
+	isChanged := false
+	focus := -1
+
+	for idx := range state.chars {
+		if idx > 0 {
+			imgui.SameLine()
+		}
+
+		if state.focus == idx {
+			imgui.SetKeyboardFocusHere()
+		}
+
+		text := ""
+		if state.chars[idx] != 0 {
+			text = string(state.chars[idx])
+		}
+
+		imgui.PushItemWidth(boxWidth)
+		changed := imgui.InputTextV(fmt.Sprintf("##%d", idx), &text, int(c.flags|InputTextFlagsAutoSelectAll), nil)
+		imgui.PopItemWidth()
+
+		switch {
+		case changed:
+			isChanged = true
+			input := []rune(text)
+
+			if len(input) == 0 {
+				state.chars[idx] = 0
+				break
+			}
+
+			focus = fillCodeInput(state.chars, idx, input)
+		case imgui.IsItemActive() && state.chars[idx] == 0 && idx > 0 && IsKeyPressed(KeyBackspace):
+			isChanged = true
+			state.chars[idx-1] = 0
+			focus = idx - 1
+		}
+	}
+
+	state.focus = focus
+
+	if !isChanged {
+		return
+	}
+
+	*c.value = codeInputValue(state.chars)
+
+	if c.onComplete != nil && len([]rune(*c.value)) == c.length {
+		c.onComplete(*c.value)
+	}
+}
+
+// fillCodeInput puts input into chars starting at idx (input longer than
+// one character is pasted into the following boxes). It returns index of box,
+// which should be focused next.
+func fillCodeInput(chars []rune, idx int, input []rune) (next int) {
+	next = idx
+	for _, r := range input {
+		if next >= len(chars) {
+			break
+		}
+
+		chars[next] = r
+		next++
+	}
+
+	if next >= len(chars) {
+		next = len(chars) - 1
+	}
+
+	return next
+}
+
+// codeInputValue joins characters of non-empty boxes.
+func codeInputValue(chars []rune) string {
+	result := make([]rune, 0, len(chars))
+	for _, r := range chars {
+		if r != 0 {
+			result = append(result, r)
+		}
+	}
+
+	return string(result)
+}
+
+// addTag appends text (trimmed) to tags. It returns false if the text is empty
+// or if tags already contain it and duplicates aren't allowed.
+func addTag(tags []string, text string, allowDuplicates bool) ([]string, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return tags, false
+	}
+
+	if !allowDuplicates {
+		for _, tag := range tags {
+			if tag == text {
+				return tags, false
+			}
+		}
+	}
+
+	return append(tags, text), true
+}
+
+var _ Disposable = &tagsInputState{}
+
+type tagsInputState struct {
+	text string
+	// comma was typed; the text should be added as a tag
+	commit bool
+	// imgui's buffer of the active input should be cleared in the next callback
+	clearText bool
+	// the input should be focused in the next frame
+	focus bool
+}
+
+// Dispose implements Disposable interface.
+func (s *tagsInputState) Dispose() {
+	// noop
+}
+
+var _ Widget = &TagsInputWidget{}
+
+// TagsInputWidget is an input for a list of tags.
+// Tags are displayed as chips (with a button to remove them) followed by
+// a text input; Enter or comma adds the typed text as a new tag and
+// backspace in the empty input removes the last tag.
+// Chips wrap to the next line when the row is full.
+type TagsInputWidget struct {
+	id              string
+	tags            *[]string
+	hint            string
+	allowDuplicates bool
+	onChange        func()
+}
+
+// TagsInput creates a new TagsInputWidget.
+func TagsInput(tags *[]string) *TagsInputWidget {
+	return &TagsInputWidget{
+		id:   GenAutoID("TagsInput"),
+		tags: tags,
+	}
+}
+
+// ID allows to manually set widget's id.
+func (t *TagsInputWidget) ID(id string) *TagsInputWidget {
+	t.id = id
+	return t
+}
+
+// Hint sets hint displayed in the empty input.
+func (t *TagsInputWidget) Hint(hint string) *TagsInputWidget {
+	t.hint = hint
+	return t
+}
+
+// AllowDuplicates allows to add a tag which is already in the list
+// (duplicates are rejected by default).
+func (t *TagsInputWidget) AllowDuplicates(allow bool) *TagsInputWidget {
+	t.allowDuplicates = allow
+	return t
+}
+
+// OnChange sets callback called when a tag is added or removed.
+func (t *TagsInputWidget) OnChange(onChange func()) *TagsInputWidget {
+	t.onChange = onChange
+	return t
+}
+
+func (t *TagsInputWidget) getState() (state *tagsInputState) {
+	if s := Context.GetState(t.id); s == nil {
+		state = &tagsInputState{}
+		Context.SetState(t.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*tagsInputState)
+		Assert(isOk, "TagsInputWidget", "getState", "unexpected state recovered")
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (t *TagsInputWidget) Build() {
+	if t.tags == nil {
+		return
+	}
+
+	state := t.getState()
+
+	imgui.PushID(t.id)
+	defer imgui.PopID()
+
+	const inputMinWidth = 100
+
+	availableW, _ := GetAvailableRegion()
+	spacingX, _ := GetItemSpacing()
+	framePadX := imgui.CurrentStyle().FramePadding().X
+	closeW, _ := CalcTextSize("x")
+
+	// width of the current row (0 if the next item starts a new row)
+	rowW := float32(0)
+	removeIdx := -1
+
+	imgui.PushStyleVarFloat(imgui.StyleVarFrameRounding, imgui.FontSize())
+	imgui.PushStyleColor(imgui.StyleColorButton, imgui.CurrentStyle().GetColor(imgui.StyleColorHeader))
+
+	for i, tag := range *t.tags {
+		tagW, _ := CalcTextSize(tag)
+		chipW := tagW + closeW + 4*framePadX
+
+		if rowW > 0 && rowW+spacingX+chipW <= availableW {
+			imgui.SameLine()
+			rowW += spacingX
+		} else {
+			rowW = 0
+		}
+
+		rowW += chipW
+
+		imgui.SmallButton(fmt.Sprintf("%s##tag%d", tag, i))
+		imgui.SameLineV(0, 0)
+
+		if imgui.SmallButton(fmt.Sprintf("x##remove%d", i)) {
+			removeIdx = i
+		}
+	}
+
+	imgui.PopStyleColor()
+	imgui.PopStyleVar()
+
+	inputW := availableW
+	if rowW > 0 && rowW+spacingX+inputMinWidth <= availableW {
+		imgui.SameLine()
+		inputW = availableW - rowW - spacingX
+	}
+
+	if state.focus {
+		imgui.SetKeyboardFocusHere()
+		state.focus = false
+	}
+
+	// backspace removes a tag only if there was no text to delete
+	wasEmpty := state.text == ""
+
+	imgui.PushItemWidth(inputW)
+	isEnter := imgui.InputTextWithHint(
+		"##input",
+		t.hint,
+		&state.text,
+		int(InputTextFlagsEnterReturnsTrue|InputTextFlagsCallbackCharFilter|InputTextFlagsCallbackAlways),
+		func(data imgui.InputTextCallbackData) int32 {
+			switch InputTextFlags(data.EventFlag()) {
+			case InputTextFlagsCallbackAlways:
+				if state.clearText {
+					data.DeleteBytes(0, len(data.Buffer()))
+					state.clearText = false
+				}
+			case InputTextFlagsCallbackCharFilter:
+				if data.EventChar() == ',' {
+					state.commit = true
+					// discard the comma
+					return 1
+				}
+			}
+
+			return 0
+		},
+	)
+	imgui.PopItemWidth()
+
+	// holding backspace doesn't remove all the tags
+	isBackspace := imgui.IsKeyPressedV(int(KeyBackspace), false)
+	if imgui.IsItemActive() && wasEmpty && len(*t.tags) > 0 && isBackspace {
+		removeIdx = len(*t.tags) - 1
+	}
+
+	isChanged := false
+
+	if isEnter || state.commit {
+		state.commit = false
+
+		if tags, ok := addTag(*t.tags, state.text, t.allowDuplicates); ok {
+			*t.tags = tags
+			state.text = ""
+			// the input is still active after the comma, so imgui would restore its text
+			state.clearText = !isEnter
+			isChanged = true
+		}
+
+		// Enter deactivates the input
+		state.focus = isEnter
+	}
+
+	if removeIdx >= 0 && removeIdx < len(*t.tags) {
+		*t.tags = append((*t.tags)[:removeIdx], (*t.tags)[removeIdx+1:]...)
+		isChanged = true
+	}
+
+	if isChanged && t.onChange != nil {
+		t.onChange()
+	}
+}
+
+// WizardStep represents a step of WizardWidget.
+type WizardStep struct {
+	Title  string
+	Layout Layout
+	// CanAdvance (if not nil) decides if user can go to the next step
+	// (e.g. if all required fields are filled).
+	CanAdvance func() bool
+}
+
+var _ Disposable = &wizardState{}
+
+type wizardState struct {
+	current int
+}
+
+// Dispose implements Disposable interface.
+func (s *wizardState) Dispose() {
+	// noop
+}
+
+var _ Widget = &WizardWidget{}
+
+// WizardWidget displays a multi-step flow: an indicator of steps,
+// layout of the current step and Back/Next buttons.
+type WizardWidget struct {
+	id       string
+	steps    []WizardStep
+	onFinish func()
+}
+
+// Wizard creates a new WizardWidget.
+func Wizard(steps ...WizardStep) *WizardWidget {
+	return &WizardWidget{
+		id:    GenAutoID("Wizard"),
+		steps: steps,
+	}
+}
+
+// ID allows to manually set widget's id.
+func (w *WizardWidget) ID(id string) *WizardWidget {
+	w.id = id
+	return w
+}
+
+// OnFinish sets callback called when user clicks Finish on the last step.
+func (w *WizardWidget) OnFinish(onFinish func()) *WizardWidget {
+	w.onFinish = onFinish
+	return w
+}
+
+func (w *WizardWidget) getState() (state *wizardState) {
+	if s := Context.GetState(w.id); s == nil {
+		state = &wizardState{}
+		Context.SetState(w.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*wizardState)
+		Assert(isOk, "WizardWidget", "getState", "unexpected state recovered")
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (w *WizardWidget) Build() {
+	if len(w.steps) == 0 {
+		return
+	}
+
+	state := w.getState()
+	if state.current >= len(w.steps) {
+		state.current = len(w.steps) - 1
+	}
+
+	w.buildIndicator(state.current)
+
+	imgui.Separator()
+
+	step := w.steps[state.current]
+	step.Layout.Build()
+
+	imgui.Separator()
+
+	isLast := state.current == len(w.steps)-1
+	canAdvance := step.CanAdvance == nil || step.CanAdvance()
+
+	nextLabel := "Next"
+	if isLast {
+		nextLabel = "Finish"
+	}
+
+	Row(
+		Style().SetDisabled(state.current == 0).To(
+			Button(fmt.Sprintf("Back##%s", w.id)).OnClick(func() {
+				state.current--
+			}),
+		),
+		Style().SetDisabled(!canAdvance).To(
+			Button(fmt.Sprintf("%s##%s", nextLabel, w.id)).OnClick(func() {
+				if !isLast {
+					state.current++
+					return
+				}
+
+				if w.onFinish != nil {
+					w.onFinish()
+				}
+			}),
+		),
+	).Build()
+}
+
+// buildIndicator displays titles of the steps (1 - 2 - 3) with the current one highlighted.
+func (w *WizardWidget) buildIndicator(current int) {
+	style := imgui.CurrentStyle()
+	disabledColor := style.GetColor(imgui.StyleColorTextDisabled)
+
+	for idx, step := range w.steps {
+		if idx > 0 {
+			imgui.SameLine()
+			imgui.PushStyleColor(imgui.StyleColorText, disabledColor)
+			imgui.Text("-")
+			imgui.PopStyleColor()
+			imgui.SameLine()
+		}
+
+		col := disabledColor
+
+		switch {
+		case idx == current:
+			col = style.GetColor(imgui.StyleColorText)
+		case idx < current:
+			col = style.GetColor(imgui.StyleColorCheckMark)
+		}
+
+		imgui.PushStyleColor(imgui.StyleColorText, col)
+		imgui.Text(fmt.Sprintf("%d. %s", idx+1, tStr(step.Title)))
+		imgui.PopStyleColor()
+	}
+}
+
+var _ Disposable = &autoSaveState{}
+
+type autoSaveState struct {
+	isDirty bool
+	// time elapsed since last change (accumulated frame by frame)
+	sinceChange time.Duration
+	lastFrame   time.Time
+	lastSaved   time.Time
+}
+
+// Dispose implements Disposable interface.
+func (s *autoSaveState) Dispose() {
+	// noop
+}
+
+var _ Widget = &AutoSaveWidget{}
+
+// AutoSaveWidget calls save callback when user edited any input
+// of the layout and the interval has elapsed since the last edit.
+// NOTE: an edit is detected when user interacts (types, drags, clicks)
+// with any of the layout's widgets, so save may be called even if
+// the value hasn't been actually changed.
+type AutoSaveWidget struct {
+	id       string
+	interval time.Duration
+	save     func()
+	layout   Layout
+}
+
+// AutoSave creates a new AutoSaveWidget.
+func AutoSave(interval time.Duration, save func()) *AutoSaveWidget {
+	return &AutoSaveWidget{
+		id:       GenAutoID("AutoSave"),
+		interval: interval,
+		save:     save,
+	}
+}
+
+// ID allows to manually set widget's id.
+func (a *AutoSaveWidget) ID(id string) *AutoSaveWidget {
+	a.id = id
+	return a
+}
+
+// To sets the form layout.
+func (a *AutoSaveWidget) To(widgets ...Widget) *AutoSaveWidget {
+	a.layout = Layout(widgets)
+	return a
+}
+
+// LastSaved returns time of the last save (zero if nothing has been saved yet).
+// In IndexedRangeBuilder it should be called while the widget is built
+// (e.g. in a Custom widget of the layout), see IndexedRangeBuilder.
+func (a *AutoSaveWidget) LastSaved() time.Time {
+	return a.getState().lastSaved
+}
+
+func (a *AutoSaveWidget) getState() (state *autoSaveState) {
+	if s := Context.GetState(a.id); s == nil {
+		state = &autoSaveState{}
+		Context.SetState(a.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*autoSaveState)
+		Assert(isOk, "AutoSaveWidget", "getState", "unexpected state recovered")
+	}
+
+	return state
+}
+
+// Build implements Widget interface.
+func (a *AutoSaveWidget) Build() {
+	state := a.getState()
+
+	imgui.BeginGroup()
+	a.layout.Build()
+	imgui.EndGroup()
+
+	// imgui-go doesn't expose frame's delta time - measure it here
+	now := time.Now()
+	if !state.lastFrame.IsZero() {
+		state.sinceChange += now.Sub(state.lastFrame)
+	}
+
+	state.lastFrame = now
+
+	// group is active when any of its items is active
+	if imgui.IsItemActive() && isUserInputInFrame() {
+		state.isDirty = true
+		state.sinceChange = 0
+	}
+
+	if !state.isDirty {
+		return
+	}
+
+	if state.sinceChange < a.interval {
+		// keep rendering frames until the save
+		Update()
+		return
+	}
+
+	state.isDirty = false
+	state.lastSaved = now
+
+	if a.save != nil {
+		a.save()
+	}
+}
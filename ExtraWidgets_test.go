@@ -0,0 +1,274 @@
+package giu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fillCodeInput(t *testing.T) {
+	tests := []struct {
+		name          string
+		chars         string
+		idx           int
+		input         string
+		expectedChars string
+		expectedNext  int
+	}{
+		{"type first", "____", 0, "1", "1___", 1},
+		{"type middle", "12__", 2, "3", "123_", 3},
+		{"type last", "123_", 3, "4", "1234", 3},
+		{"paste all", "____", 0, "1234", "1234", 3},
+		{"paste too long", "____", 1, "56789", "_567", 3},
+		{"paste part", "____", 1, "56", "_56_", 3},
+	}
+
+	// '_' represents an empty box
+	toChars := func(s string) []rune {
+		chars := []rune(s)
+		for i, r := range chars {
+			if r == '_' {
+				chars[i] = 0
+			}
+		}
+
+		return chars
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			chars := toChars(tc.chars)
+			next := fillCodeInput(chars, tc.idx, []rune(tc.input))
+			assert.Equal(t, toChars(tc.expectedChars), chars, "unexpected boxes content")
+			assert.Equal(t, tc.expectedNext, next, "unexpected box to focus")
+		})
+	}
+}
+
+func Test_codeInputValue(t *testing.T) {
+	assert.Equal(t, "", codeInputValue([]rune{0, 0}), "empty boxes should give empty value")
+	assert.Equal(t, "13", codeInputValue([]rune{'1', 0, '3'}), "empty boxes should be skipped")
+	assert.Equal(t, "123", codeInputValue([]rune{'1', '2', '3'}), "unexpected value")
+}
+
+func Test_memoDepsEqual(t *testing.T) {
+	value := 1
+
+	tests := []struct {
+		name     string
+		a, b     []interface{}
+		expected bool
+	}{
+		{"no deps", nil, []interface{}{}, true},
+		{"equal", []interface{}{1, "a", true}, []interface{}{1, "a", true}, true},
+		{"different value", []interface{}{1, "a"}, []interface{}{1, "b"}, false},
+		{"different length", []interface{}{1}, []interface{}{1, 2}, false},
+		{"different type", []interface{}{1}, []interface{}{int64(1)}, false},
+		{"same pointer", []interface{}{&value}, []interface{}{&value}, true},
+		{"nil", []interface{}{nil}, []interface{}{nil}, true},
+		{"nil and value", []interface{}{nil}, []interface{}{1}, false},
+		{"not comparable", []interface{}{[]int{1}}, []interface{}{[]int{1}}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, memoDepsEqual(tc.a, tc.b))
+		})
+	}
+}
+
+func Test_MemoWidget_AutoIDs(t *testing.T) {
+	newTestContext(t)
+
+	var value string
+
+	// frame returns auto ID of an input built after the Memo
+	frame := func(dep int) string {
+		Context.widgetIndexCounter = 0
+
+		imgui.NewFrame()
+		imgui.Begin("memo")
+
+		Memo(dep).ID("memoAutoIDs").To(func() Widget {
+			return InputText(&value)
+		}).Build()
+
+		after := InputText(&value)
+		after.Build()
+
+		imgui.End()
+		imgui.Render()
+
+		return after.label
+	}
+
+	constructed := frame(1)
+	assert.Equal(t, constructed, frame(1), "ID after a cached Memo changed")
+	assert.Equal(t, constructed, frame(2), "ID after a reconstructed Memo changed")
+}
+
+func Test_pagerPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  int
+		total    int
+		expected []int
+	}{
+		{"single page", 1, 1, []int{1}},
+		{"few pages", 2, 5, []int{1, 2, 3, 4, 5}},
+		{"start", 1, 20, []int{1, 2, 3, 0, 20}},
+		{"middle", 10, 20, []int{1, 0, 8, 9, 10, 11, 12, 0, 20}},
+		{"end", 20, 20, []int{1, 0, 18, 19, 20}},
+		{"single gap at start", 5, 20, []int{1, 2, 3, 4, 5, 6, 7, 0, 20}},
+		{"single gap at end", 16, 20, []int{1, 0, 14, 15, 16, 17, 18, 19, 20}},
+		{"many pages", 500000, 1000000, []int{1, 0, 499998, 499999, 500000, 500001, 500002, 0, 1000000}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, pagerPages(tc.current, tc.total))
+		})
+	}
+}
+
+func Test_addTag(t *testing.T) {
+	tests := []struct {
+		name            string
+		tags            []string
+		text            string
+		allowDuplicates bool
+		want            []string
+		wantOk          bool
+	}{
+		{"new tag", []string{"go"}, "imgui", false, []string{"go", "imgui"}, true},
+		{"trimmed", nil, "  go ", false, []string{"go"}, true},
+		{"empty", []string{"go"}, "   ", false, []string{"go"}, false},
+		{"duplicate", []string{"go"}, "go", false, []string{"go"}, false},
+		{"duplicate allowed", []string{"go"}, "go", true, []string{"go", "go"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := addTag(tt.tags, tt.text, tt.allowDuplicates)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_TagsInputWidget(t *testing.T) {
+	io := newTestContext(t)
+	io.KeyMap(imgui.KeyBackspace, int(KeyBackspace))
+
+	tags := []string{"x", "y"}
+	changes := 0
+
+	frame := func(focus bool) {
+		imgui.NewFrame()
+		imgui.Begin("tags input")
+
+		if focus {
+			SetKeyboardFocusHere()
+		}
+
+		TagsInput(&tags).ID("tagsInputTest").OnChange(func() { changes++ }).Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame(true)
+	frame(false)
+
+	io.AddInputCharacters("a,")
+	frame(false)
+	frame(false)
+
+	io.AddInputCharacters("b,")
+	frame(false)
+	frame(false)
+
+	assert.Equal(t, []string{"x", "y", "a", "b"}, tags, "comma should add the tag and clear the input")
+	assert.Equal(t, 2, changes)
+
+	// hold backspace long enough to trigger key repeat
+	io.KeyPress(int(KeyBackspace))
+
+	for i := 0; i < 60; i++ {
+		frame(false)
+	}
+
+	io.KeyRelease(int(KeyBackspace))
+	frame(false)
+
+	assert.Equal(t, []string{"x", "y", "a"}, tags, "holding backspace should remove one tag")
+	assert.Equal(t, 3, changes)
+}
+
+func Test_IndexedRangeBuilder_InputTextState(t *testing.T) {
+	io := newTestContext(t)
+
+	values := []string{"", ""}
+
+	frame := func(focus bool) {
+		imgui.NewFrame()
+		imgui.Begin("indexed rows")
+
+		IndexedRangeBuilder("indexedRowsTest", len(values), func(i int) Widget {
+			return Custom(func() {
+				if focus && i == 1 {
+					SetKeyboardFocusHere()
+				}
+
+				// the same label in each row
+				InputText(&values[i]).Label("##name").Build()
+			})
+		}).Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame(true)
+	frame(false)
+
+	io.AddInputCharacters("abc")
+	frame(false)
+
+	assert.Equal(t, []string{"", "abc"}, values, "only the focused row should be edited")
+
+	rowState := func(i int) *inputTextState {
+		Context.pushIDScope(fmt.Sprintf("indexedRowsTest/%d", i))
+		defer Context.popIDScope()
+
+		s, ok := Context.GetState("##name").(*inputTextState)
+		assert.True(t, ok, "no state of row %d", i)
+
+		return s
+	}
+
+	first, second := rowState(0), rowState(1)
+	assert.NotSame(t, first, second, "rows share the state")
+	assert.False(t, first.isActive, "first row's state shouldn't be active")
+	assert.True(t, second.isActive, "second row's state should be active")
+}
+
+func Test_IndexedRangeBuilder_PanickingRow(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("panicking row")
+
+	assert.Panics(t, func() {
+		IndexedRangeBuilder("panickingRowTest", 1, func(int) Widget {
+			return Custom(func() { panic("build failed") })
+		}).Build()
+	}, "row's panic should be propagated")
+
+	assert.Empty(t, Context.idScope, "row's id scope should be popped")
+
+	imgui.End()
+	imgui.Render()
+}
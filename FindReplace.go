@@ -0,0 +1,295 @@
+package giu
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/AllenDang/imgui-go"
+)
+
+// findMatches returns byte ranges ([start, end)) of all non-overlapping
+// occurrences of query in text.
+func findMatches(text, query string, caseSensitive bool) (matches [][2]int) {
+	if query == "" {
+		return nil
+	}
+
+	for i := 0; i+len(query) <= len(text); {
+		candidate := text[i : i+len(query)]
+		if candidate == query || (!caseSensitive && strings.EqualFold(candidate, query)) {
+			matches = append(matches, [2]int{i, i + len(query)})
+			i += len(query)
+
+			continue
+		}
+
+		// move to the next rune
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+
+	return matches
+}
+
+// replaceMatches replaces all occurrences of query in text with replacement.
+func replaceMatches(text, query, replacement string, caseSensitive bool) string {
+	matches := findMatches(text, query, caseSensitive)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var result strings.Builder
+
+	last := 0
+	for _, m := range matches {
+		result.WriteString(text[last:m[0]])
+		result.WriteString(replacement)
+		last = m[1]
+	}
+
+	result.WriteString(text[last:])
+
+	return result.String()
+}
+
+var _ Disposable = &findReplaceState{}
+
+type findReplaceState struct {
+	isOpen        bool
+	find          string
+	replace       string
+	caseSensitive bool
+	// last known selection in the multiline input (byte offsets)
+	selection [2]int
+	// selection to be set in the next callback
+	pendingSelection *[2]int
+	focusFind        bool
+	focusInput       bool
+}
+
+// Dispose implements Disposable interface.
+func (s *findReplaceState) Dispose() {
+	// noop
+}
+
+// callback wraps user's callback; it applies pending selection and tracks the current one.
+func (s *findReplaceState) callback(userCb imgui.InputTextCallback, userFlags InputTextFlags) imgui.InputTextCallback {
+	return func(data imgui.InputTextCallbackData) int32 {
+		eventFlag := InputTextFlags(data.EventFlag())
+
+		if eventFlag == InputTextFlagsCallbackAlways {
+			if s.pendingSelection != nil {
+				data.SetSelectionStart(s.pendingSelection[0])
+				data.SetSelectionEnd(s.pendingSelection[1])
+				data.SetCursorPos(s.pendingSelection[1])
+				s.pendingSelection = nil
+			}
+
+			start, end := data.SelectionStart(), data.SelectionEnd()
+			if start > end {
+				start, end = end, start
+			}
+
+			s.selection = [2]int{start, end}
+		}
+
+		if userCb != nil && userFlags&eventFlag != 0 {
+			return userCb(data)
+		}
+
+		return 0
+	}
+}
+
+// selectNext selects the first match after the current selection (wrapping around).
+func (s *findReplaceState) selectNext(text string) {
+	matches := findMatches(text, s.find, s.caseSensitive)
+	if len(matches) == 0 {
+		return
+	}
+
+	next := matches[0]
+	for _, m := range matches {
+		if m[0] >= s.selection[1] {
+			next = m
+			break
+		}
+	}
+
+	s.pendingSelection = &next
+	s.focusInput = true
+}
+
+// replaceCurrent replaces the selected match (if any) and selects the next one.
+// It returns true if the text was changed.
+func (s *findReplaceState) replaceCurrent(text *string) (isReplaced bool) {
+	for _, m := range findMatches(*text, s.find, s.caseSensitive) {
+		if m != s.selection {
+			continue
+		}
+
+		*text = (*text)[:m[0]] + s.replace + (*text)[m[1]:]
+		s.selection = [2]int{m[0], m[0] + len(s.replace)}
+		isReplaced = true
+
+		break
+	}
+
+	s.selectNext(*text)
+
+	return isReplaced
+}
+
+// buildBar builds the find bar; it returns true if the text was changed
+// by Replace or Replace all.
+func (s *findReplaceState) buildBar(id string, text *string) (isReplaced bool) {
+	if !s.isOpen {
+		return false
+	}
+
+	matches := findMatches(*text, s.find, s.caseSensitive)
+
+	current := 0
+	for n, m := range matches {
+		if m == s.selection {
+			current = n + 1
+			break
+		}
+	}
+
+	const fieldWidth = 200
+
+	// id of the input usually starts with "##", so it can't be appended to the labels
+	// (e.g. "Next####input" would be identified by "###input" only)
+	imgui.PushID(id)
+	defer imgui.PopID()
+
+	if s.focusFind {
+		imgui.SetKeyboardFocusHere()
+		s.focusFind = false
+	}
+
+	imgui.PushItemWidth(fieldWidth)
+	// Enter selects the next match
+	if imgui.InputTextWithHint("##find", "Find", &s.find, int(InputTextFlagsEnterReturnsTrue), nil) {
+		s.selectNext(*text)
+	}
+
+	imgui.PopItemWidth()
+
+	imgui.SameLine()
+	imgui.Checkbox("Aa##caseSensitive", &s.caseSensitive)
+
+	if imgui.IsItemHovered() {
+		imgui.SetTooltip("Case sensitive")
+	}
+
+	imgui.SameLine()
+
+	if imgui.Button("Next") {
+		s.selectNext(*text)
+	}
+
+	imgui.SameLine()
+	imgui.Text(fmt.Sprintf("%d/%d", current, len(matches)))
+	imgui.SameLine()
+
+	if imgui.Button("x##close") {
+		s.isOpen = false
+	}
+
+	imgui.PushItemWidth(fieldWidth)
+	imgui.InputTextWithHint("##replace", "Replace", &s.replace, 0, nil)
+	imgui.PopItemWidth()
+	imgui.SameLine()
+
+	if imgui.Button("Replace") {
+		isReplaced = s.replaceCurrent(text)
+	}
+
+	imgui.SameLine()
+
+	if imgui.Button("Replace all") {
+		replaced := replaceMatches(*text, s.find, s.replace, s.caseSensitive)
+		isReplaced = replaced != *text
+		*text = replaced
+	}
+
+	return isReplaced
+}
+
+// handleInput is called after the multiline input is built; it opens
+// the find bar on Ctrl+F and highlights the matches.
+func (s *findReplaceState) handleInput(text string) {
+	isCtrlDown := IsKeyDown(KeyLeftControl) || IsKeyDown(KeyRightControl)
+	if imgui.IsItemActive() && isCtrlDown && IsKeyPressed(KeyF) {
+		s.isOpen = true
+		s.focusFind = true
+
+		// search for the selected text
+		if s.selection[1] <= len(text) {
+			if selected := text[s.selection[0]:s.selection[1]]; selected != "" && !strings.Contains(selected, "\n") {
+				s.find = selected
+			}
+		}
+	}
+
+	if !s.isOpen {
+		return
+	}
+
+	s.highlightMatches(text)
+}
+
+// highlightMatches draws rectangles over matches in the multiline input.
+// NOTE: the scroll position of the input isn't available, so
+// the matches are highlighted only if the whole text fits in the input.
+func (s *findReplaceState) highlightMatches(text string) {
+	matches := findMatches(text, s.find, s.caseSensitive)
+	if len(matches) == 0 {
+		return
+	}
+
+	itemMin, itemMax := imgui.GetItemRectMin(), imgui.GetItemRectMax()
+	padding := imgui.CurrentStyle().FramePadding()
+	textWidth, textHeight := CalcTextSize(text)
+
+	if textWidth > itemMax.X-itemMin.X-2*padding.X || textHeight > itemMax.Y-itemMin.Y-2*padding.Y {
+		return
+	}
+
+	col := imgui.CurrentStyle().GetColor(imgui.StyleColorTextSelectedBg)
+	currentColor := Vec4ToRGBA(col)
+	col.W *= 0.5
+	matchColor := Vec4ToRGBA(col)
+
+	lineHeight := imgui.TextLineHeight()
+	canvas := GetCanvas()
+
+	for _, m := range matches {
+		lineStart := strings.LastIndexByte(text[:m[0]], '\n') + 1
+		line := strings.Count(text[:m[0]], "\n")
+		x0, _ := CalcTextSize(text[lineStart:m[0]])
+		w, _ := CalcTextSize(text[m[0]:m[1]])
+
+		pos := imgui.Vec2{
+			X: itemMin.X + padding.X + x0,
+			Y: itemMin.Y + padding.Y + float32(line)*lineHeight,
+		}
+
+		c := matchColor
+		if m == s.selection {
+			c = currentColor
+		}
+
+		canvas.AddRectFilled(
+			image.Pt(int(pos.X), int(pos.Y)),
+			image.Pt(int(pos.X+w), int(pos.Y+lineHeight)),
+			c,
+			0,
+			DrawFlagsRoundCornersNone,
+		)
+	}
+}
@@ -0,0 +1,117 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findMatches(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		query         string
+		caseSensitive bool
+		expected      [][2]int
+	}{
+		{"empty query", "abc", "", false, nil},
+		{"no match", "abc", "d", false, nil},
+		{"multiple", "foo bar foo", "foo", true, [][2]int{{0, 3}, {8, 11}}},
+		{"case sensitive", "Foo foo", "foo", true, [][2]int{{4, 7}}},
+		{"case insensitive", "Foo foo", "foo", false, [][2]int{{0, 3}, {4, 7}}},
+		{"non-overlapping", "aaaa", "aa", true, [][2]int{{0, 2}, {2, 4}}},
+		{"after multibyte", "zażółć ć", "ć", true, [][2]int{{8, 10}, {11, 13}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, findMatches(tc.text, tc.query, tc.caseSensitive))
+		})
+	}
+}
+
+func Test_replaceMatches(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		query         string
+		replacement   string
+		caseSensitive bool
+		expected      string
+	}{
+		{"no match", "abc", "d", "e", false, "abc"},
+		{"replace all", "foo bar foo", "foo", "baz", true, "baz bar baz"},
+		{"case sensitive", "Foo foo", "foo", "x", true, "Foo x"},
+		{"case insensitive", "Foo foo", "foo", "x", false, "x x"},
+		{"remove", "a-b-c", "-", "", true, "abc"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, replaceMatches(tc.text, tc.query, tc.replacement, tc.caseSensitive))
+		})
+	}
+}
+
+func Test_InputTextMultilineWidget_Replace(t *testing.T) {
+	io := newTestContext(t)
+
+	text := "foo bar foo"
+	label := "##findReplace"
+
+	var (
+		changes      int
+		changedText  string
+		changedPos   int
+		replacePos   imgui.Vec2
+		replaceAllAt imgui.Vec2
+	)
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.Begin("find replace")
+
+		// the second row of the find bar: replace field, Replace, Replace all
+		start := imgui.CursorScreenPos()
+		spacing := imgui.CurrentStyle().ItemSpacing()
+		y := start.Y + imgui.FrameHeightWithSpacing() + frameHeight()/2
+		replaceW, _ := CalcTextSize("Replace")
+		replaceW += 2 * imgui.CurrentStyle().FramePadding().X
+		replacePos = imgui.Vec2{X: start.X + 200 + spacing.X + replaceW/2, Y: y}
+		replaceAllAt = imgui.Vec2{X: start.X + 200 + spacing.X + replaceW + spacing.X + 5, Y: y}
+
+		InputTextMultiline(&text).
+			Label(label).
+			EnableFindReplace(true).
+			OnChange(func() { changes++ }).
+			OnChangeEx(func(text string, cursorBytePos int) {
+				changedText, changedPos = text, cursorBytePos
+			}).
+			Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	Context.SetState(label+"##findReplace", &findReplaceState{
+		isOpen:    true,
+		find:      "foo",
+		replace:   "baz",
+		selection: [2]int{0, 3},
+	})
+
+	frame()
+	frame()
+
+	clickAt(io, replacePos, frame)
+	assert.Equal(t, "baz bar foo", text, "Replace should replace the selected match")
+	assert.Equal(t, 1, changes, "Replace should call OnChange")
+	assert.Equal(t, "baz bar foo", changedText, "Replace should call OnChangeEx")
+	assert.Equal(t, 3, changedPos, "cursor should be after the replacement")
+
+	clickAt(io, replaceAllAt, frame)
+	assert.Equal(t, "baz bar baz", text, "Replace all should replace all matches")
+	assert.Equal(t, 2, changes, "Replace all should call OnChange")
+	assert.Equal(t, "baz bar baz", changedText, "Replace all should call OnChangeEx")
+}
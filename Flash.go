@@ -0,0 +1,130 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/AllenDang/imgui-go"
+)
+
+// flashAnimation animates a color towards the flash color and back
+// (see FlashRect and (*InputTextWidget).Flash).
+type flashAnimation struct {
+	color    color.Color
+	start    time.Time
+	duration time.Duration
+}
+
+func (f *flashAnimation) run(col color.Color, duration time.Duration) {
+	f.color = col
+	f.start = time.Now()
+	f.duration = duration
+}
+
+// factor returns intensity of the flash (in range 0-1) in the current frame.
+// While the animation is running, it keeps the frames rendering.
+func (f *flashAnimation) factor() float32 {
+	if f.duration <= 0 {
+		return 0
+	}
+
+	elapsed := time.Since(f.start)
+	if elapsed >= f.duration {
+		f.duration = 0
+		return 0
+	}
+
+	// imgui doesn't render new frames if there is no user input
+	Update()
+
+	return float32(math.Sin(math.Pi * float64(elapsed) / float64(f.duration)))
+}
+
+// lerpVec4 interpolates linearly between a and b.
+func lerpVec4(a, b imgui.Vec4, t float32) imgui.Vec4 {
+	return imgui.Vec4{
+		X: a.X + (b.X-a.X)*t,
+		Y: a.Y + (b.Y-a.Y)*t,
+		Z: a.Z + (b.Z-a.Z)*t,
+		W: a.W + (b.W-a.W)*t,
+	}
+}
+
+var _ Disposable = &flashRectState{}
+
+type flashRectState struct {
+	flashAnimation
+}
+
+// Dispose implements Disposable interface.
+func (s *flashRectState) Dispose() {
+	// noop
+}
+
+var _ Widget = &FlashRectWidget{}
+
+// FlashRectWidget draws a flashing rectangle over the previous widget
+// to draw user's attention (put it after the widget like giu.Event()).
+type FlashRectWidget struct {
+	id       string
+	color    color.Color
+	duration time.Duration
+	trigger  bool
+}
+
+// FlashRect creates a new FlashRectWidget.
+func FlashRect(col color.Color, duration time.Duration) *FlashRectWidget {
+	return &FlashRectWidget{
+		id:       GenAutoID("FlashRect"),
+		color:    col,
+		duration: duration,
+	}
+}
+
+// Trigger starts the flash animation if trigger is true.
+func (f *FlashRectWidget) Trigger(trigger bool) *FlashRectWidget {
+	f.trigger = trigger
+	return f
+}
+
+// ID allows to manually set widget's id.
+func (f *FlashRectWidget) ID(id string) *FlashRectWidget {
+	f.id = id
+	return f
+}
+
+// Build implements Widget interface.
+func (f *FlashRectWidget) Build() {
+	var state *flashRectState
+	if s := Context.GetState(f.id); s == nil {
+		state = &flashRectState{}
+		Context.SetState(f.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*flashRectState)
+		Assert(isOk, "FlashRectWidget", "Build", "unexpected state recovered")
+	}
+
+	if f.trigger {
+		state.run(f.color, f.duration)
+	}
+
+	factor := state.factor()
+	if factor <= 0 {
+		return
+	}
+
+	col := ToVec4Color(state.color)
+	col.W *= factor
+
+	itemMin, itemMax := imgui.GetItemRectMin(), imgui.GetItemRectMax()
+	GetCanvas().AddRectFilled(
+		image.Pt(int(itemMin.X), int(itemMin.Y)),
+		image.Pt(int(itemMax.X), int(itemMax.Y)),
+		Vec4ToRGBA(col),
+		0,
+		DrawFlagsRoundCornersNone,
+	)
+}
@@ -0,0 +1,146 @@
+package giu
+
+import "github.com/AllenDang/imgui-go"
+
+var _ Disposable = &focusGroupState{}
+
+type focusGroupState struct {
+	// index of the field active in the previous frame (-1 if none)
+	activeField int
+	// index of the field to be focused in this frame (-1 if none)
+	focusTarget int
+}
+
+// Dispose implements Disposable interface.
+func (s *focusGroupState) Dispose() {
+	// noop
+}
+
+// nextFocusGroupField returns index of the next (or previous if backward is true)
+// field after current, which isn't disabled. The search wraps around.
+// It returns -1 if there is no enabled field.
+func nextFocusGroupField(disabled []bool, current int, backward bool) int {
+	n := len(disabled)
+
+	step := 1
+	if backward {
+		step = -1
+	}
+
+	for k := 1; k <= n; k++ {
+		idx := ((current+step*k)%n + n) % n
+		if !disabled[idx] {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// isWidgetDisabled returns true if w is known to be disabled.
+func isWidgetDisabled(w Widget) bool {
+	switch typed := w.(type) {
+	case *StyleSetter:
+		return typed.disabled
+	case *ButtonWidget:
+		return typed.disabled
+	}
+
+	return false
+}
+
+var _ Widget = &FocusGroupWidget{}
+
+// FocusGroupWidget makes Tab (and Shift+Tab) cycle through its fields only:
+// from the last field, the focus wraps to the first one (and vice versa).
+// A field could be any widget (e.g. a Row with a label and an input);
+// Tab focuses the first input of the field.
+// Disabled fields (wrapped in Style().SetDisabled(true)) are skipped.
+type FocusGroupWidget struct {
+	id     string
+	fields []Widget
+}
+
+// FocusGroup creates a new FocusGroupWidget.
+func FocusGroup() *FocusGroupWidget {
+	return &FocusGroupWidget{
+		id: GenAutoID("FocusGroup"),
+	}
+}
+
+// ID allows to manually set widget's id.
+func (f *FocusGroupWidget) ID(id string) *FocusGroupWidget {
+	f.id = id
+	return f
+}
+
+// To sets group's fields (in the focus order).
+func (f *FocusGroupWidget) To(fields ...Widget) *FocusGroupWidget {
+	f.fields = fields
+	return f
+}
+
+// Build implements Widget interface.
+func (f *FocusGroupWidget) Build() {
+	var state *focusGroupState
+	if s := Context.GetState(f.id); s == nil {
+		state = &focusGroupState{activeField: -1, focusTarget: -1}
+		Context.SetState(f.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*focusGroupState)
+		Assert(isOk, "FocusGroupWidget", "Build", "got unexpected type of widget's state")
+	}
+
+	disabled := make([]bool, len(f.fields))
+	for i, field := range f.fields {
+		disabled[i] = field == nil || isWidgetDisabled(field)
+	}
+
+	// Tab is handled before the fields are built, so that the focus is moved
+	// instead of imgui's own Tab handling
+	tabFrom := -1
+	if state.activeField >= 0 && state.activeField < len(f.fields) && IsKeyPressed(KeyTab) {
+		tabFrom = state.activeField
+		backward := IsKeyDown(KeyLeftShift) || IsKeyDown(KeyRightShift)
+		state.focusTarget = nextFocusGroupField(disabled, tabFrom, backward)
+
+		if state.focusTarget < 0 {
+			tabFrom = -1
+		}
+	}
+
+	state.activeField = -1
+
+	for i, field := range f.fields {
+		if field == nil {
+			continue
+		}
+
+		// imgui moves the focus from the active item on Tab, so in this frame
+		// the field is built with other ids (its item isn't active anymore)
+		isTabbedFrom := i == tabFrom
+		if isTabbedFrom {
+			imgui.PushID(f.id + "##tabbedFrom")
+		}
+
+		// the field tabbed from is focused in the next frame (with its own ids)
+		if i == state.focusTarget && !isTabbedFrom {
+			SetKeyboardFocusHere()
+			state.focusTarget = -1
+		}
+
+		// group makes IsItemActive report any active item of the field
+		imgui.BeginGroup()
+		field.Build()
+		imgui.EndGroup()
+
+		if isTabbedFrom {
+			imgui.PopID()
+		}
+
+		if imgui.IsItemActive() {
+			state.activeField = i
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package giu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_nextFocusGroupField(t *testing.T) {
+	disabled := []bool{false, true, false, false}
+
+	tests := []struct {
+		name     string
+		current  int
+		backward bool
+		expected int
+	}{
+		{"next skipping disabled", 0, false, 2},
+		{"next", 2, false, 3},
+		{"wrap forward", 3, false, 0},
+		{"wrap backward", 0, true, 3},
+		{"previous skipping disabled", 2, true, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, nextFocusGroupField(disabled, tc.current, tc.backward))
+		})
+	}
+
+	assert.Equal(t, -1, nextFocusGroupField([]bool{true, true}, 0, false), "disabled field selected")
+	assert.Equal(t, 0, nextFocusGroupField([]bool{false}, 0, false), "single field")
+}
+
+func Test_FocusGroupWidget_Tab(t *testing.T) {
+	io := newTestContext(t)
+	io.KeyMap(imgui.KeyTab, int(KeyTab))
+
+	values := make([]string, 4)
+	centers := make([]imgui.Vec2, 4)
+	active := -1
+
+	input := func(i int) Widget {
+		return Layout{
+			InputText(&values[i]).Label(fmt.Sprintf("##focusGroup%d", i)),
+			Custom(func() {
+				if imgui.IsItemActive() {
+					active = i
+				}
+
+				min, max := imgui.GetItemRectMin(), imgui.GetItemRectMax()
+				centers[i] = imgui.Vec2{X: (min.X + max.X) / 2, Y: (min.Y + max.Y) / 2}
+			}),
+		}
+	}
+
+	frame := func() {
+		active = -1
+
+		imgui.NewFrame()
+		imgui.Begin("focus group")
+
+		FocusGroup().ID("form").To(input(0), input(1), input(2)).Build()
+		input(3).Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	pressTab := func(shift bool) {
+		if shift {
+			io.KeyPress(int(KeyLeftShift))
+			io.KeyShift(int(KeyLeftShift), int(KeyRightShift))
+		}
+
+		io.KeyPress(int(KeyTab))
+		frame()
+		io.KeyRelease(int(KeyTab))
+		assert.NotEqual(t, 3, active, "focus shouldn't leave the group")
+
+		if shift {
+			io.KeyRelease(int(KeyLeftShift))
+			io.KeyShift(int(KeyLeftShift), int(KeyRightShift))
+		}
+
+		for n := 0; n < 3; n++ {
+			frame()
+			assert.NotEqual(t, 3, active, "focus shouldn't leave the group")
+		}
+	}
+
+	frame()
+	frame()
+
+	clickAt(io, centers[1], frame)
+	assert.Equal(t, 1, active, "clicked input should be active")
+
+	pressTab(false)
+	assert.Equal(t, 2, active, "Tab should focus the next field")
+
+	pressTab(false)
+	assert.Equal(t, 0, active, "Tab should wrap to the first field instead of leaving the group")
+
+	pressTab(true)
+	assert.Equal(t, 2, active, "Shift+Tab should wrap to the last field")
+}
@@ -17,6 +17,7 @@ var (
 	defaultFonts           []FontInfo
 	extraFonts             []FontInfo
 	extraFontMap           map[string]*imgui.Font
+	globalFontScale        float32 = 1
 )
 
 const (
@@ -133,6 +134,35 @@ func AddFontFromBytes(fontName string, fontBytes []byte, size float32) *FontInfo
 	return &fi
 }
 
+// addExtraFont adds font to extraFonts, unless the same font
+// (of the same size) has been already added.
+func addExtraFont(font FontInfo) *FontInfo {
+	for i := range extraFonts {
+		if extraFonts[i].String() == font.String() {
+			return &extraFonts[i]
+		}
+	}
+
+	extraFonts = append(extraFonts, font)
+
+	return &font
+}
+
+// SetGlobalFontScale scales all fonts (e.g. to zoom the UI in or out at runtime).
+// The scale is applied when the fonts are rendered, so the font atlas isn't rebuilt,
+// but scaled glyphs are rendered from textures created for the fonts'
+// original sizes: scales greater than 1 make the text blurry.
+// For a permanent size change, use SetDefaultFont (or AddFont) with a
+// different size instead.
+func SetGlobalFontScale(scale float32) {
+	globalFontScale = scale
+}
+
+// GetGlobalFontScale returns current global font scale (see SetGlobalFontScale).
+func GetGlobalFontScale() float32 {
+	return globalFontScale
+}
+
 func registerDefaultFont(fontName string, size float32) {
 	fontPath, err := findfont.Find(fontName)
 	if err != nil {
@@ -158,6 +188,52 @@ func registerDefaultFonts(fontInfos []FontInfo) {
 	}
 }
 
+// monospaceFontNames are system fonts looked up (in order) by getMonospaceFont.
+var monospaceFontNames = []string{
+	"Menlo",
+	"Consolas",
+	"DejaVuSansMono",
+	"LiberationMono-Regular",
+	"FiraCode-Medium",
+	"cour",
+}
+
+var (
+	monospaceFont           *FontInfo
+	isMonospaceFontLookedUp bool
+)
+
+// getMonospaceFont returns a monospace system font. On the first call
+// the font is added to extra fonts (font atlas gets rebuilt).
+// It returns nil if no monospace font has been found.
+func getMonospaceFont() *FontInfo {
+	if isMonospaceFontLookedUp {
+		return monospaceFont
+	}
+
+	isMonospaceFontLookedUp = true
+
+	size := float32(14)
+	if len(defaultFonts) > 0 {
+		size = defaultFonts[0].size
+	}
+
+	for _, fontName := range monospaceFontNames {
+		fontPath, err := findfont.Find(fontName)
+		if err != nil {
+			continue
+		}
+
+		monospaceFont = &FontInfo{fontName: fontName, fontPath: fontPath, size: size}
+		extraFonts = append(extraFonts, *monospaceFont)
+		shouldRebuildFontAtlas = true
+
+		break
+	}
+
+	return monospaceFont
+}
+
 // Register string to font atlas builder.
 // Note only register strings that will be displayed on the UI.
 func tStr(str string) string {
@@ -12,6 +12,57 @@ import (
 	resty "github.com/go-resty/resty/v2"
 )
 
+// FitMode defines how an image is fitted into ImageWidget's size.
+type FitMode byte
+
+// fit modes.
+const (
+	// FitStretch stretches the image to the whole size (default).
+	FitStretch FitMode = iota
+	// FitContain scales the image to fit into the size keeping its aspect ratio
+	// (the free space stays empty).
+	FitContain
+	// FitCover scales the image to cover the whole size keeping its aspect ratio
+	// (the image is cropped).
+	FitCover
+)
+
+// fitImage calculates size and position offset of the image displayed
+// in a box and fraction (uv0, uv1 in range 0-1) of the image to display.
+func fitImage(fit FitMode, imageSize image.Point, box imgui.Vec2) (size, offset, uv0, uv1 imgui.Vec2) {
+	size, uv0, uv1 = box, imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 1}
+
+	if fit == FitStretch || imageSize.X <= 0 || imageSize.Y <= 0 || box.X <= 0 || box.Y <= 0 {
+		return size, offset, uv0, uv1
+	}
+
+	scaleX, scaleY := box.X/float32(imageSize.X), box.Y/float32(imageSize.Y)
+
+	switch fit {
+	case FitContain:
+		scale := scaleX
+		if scaleY < scale {
+			scale = scaleY
+		}
+
+		size = imgui.Vec2{X: float32(imageSize.X) * scale, Y: float32(imageSize.Y) * scale}
+		offset = imgui.Vec2{X: (box.X - size.X) / 2, Y: (box.Y - size.Y) / 2}
+	case FitCover:
+		scale := scaleX
+		if scaleY > scale {
+			scale = scaleY
+		}
+
+		// visible fraction of the image
+		visibleX := box.X / (float32(imageSize.X) * scale)
+		visibleY := box.Y / (float32(imageSize.Y) * scale)
+		uv0 = imgui.Vec2{X: (1 - visibleX) / 2, Y: (1 - visibleY) / 2}
+		uv1 = imgui.Vec2{X: 1 - uv0.X, Y: 1 - uv0.Y}
+	}
+
+	return size, offset, uv0, uv1
+}
+
 var _ Widget = &ImageWidget{}
 
 // ImageWidget adds an image.
@@ -24,6 +75,7 @@ type ImageWidget struct {
 	height                 float32
 	uv0, uv1               image.Point
 	tintColor, borderColor color.Color
+	fit                    FitMode
 	onClick                func()
 }
 
@@ -56,6 +108,13 @@ func (i *ImageWidget) BorderCol(borderColor color.Color) *ImageWidget {
 	return i
 }
 
+// Fit sets how the image is fitted into the widget's size
+// (requires texture's size to be known, see (*Texture).Size).
+func (i *ImageWidget) Fit(fit FitMode) *ImageWidget {
+	i.fit = fit
+	return i
+}
+
 // OnClick adds on-click-callback.
 func (i *ImageWidget) OnClick(cb func()) *ImageWidget {
 	i.onClick = cb
@@ -97,7 +156,26 @@ func (i *ImageWidget) Build() {
 		}
 	}
 
-	imgui.ImageV(i.texture.id, size, ToVec2(i.uv0), ToVec2(i.uv1), ToVec4Color(i.tintColor), ToVec4Color(i.borderColor))
+	if i.fit == FitStretch {
+		imgui.ImageV(i.texture.id, size, ToVec2(i.uv0), ToVec2(i.uv1), ToVec4Color(i.tintColor), ToVec4Color(i.borderColor))
+		return
+	}
+
+	imageSize, offset, fitUV0, fitUV1 := fitImage(i.fit, i.texture.size, size)
+
+	// map fitted uvs into uv0-uv1 range
+	uv0, uv1 := ToVec2(i.uv0), ToVec2(i.uv1)
+	uvSize := imgui.Vec2{X: uv1.X - uv0.X, Y: uv1.Y - uv0.Y}
+	uv0, uv1 = imgui.Vec2{X: uv0.X + uvSize.X*fitUV0.X, Y: uv0.Y + uvSize.Y*fitUV0.Y},
+		imgui.Vec2{X: uv0.X + uvSize.X*fitUV1.X, Y: uv0.Y + uvSize.Y*fitUV1.Y}
+
+	start := imgui.CursorPos()
+	imgui.SetCursorPos(imgui.Vec2{X: start.X + offset.X, Y: start.Y + offset.Y})
+	imgui.ImageV(i.texture.id, imageSize, uv0, uv1, ToVec4Color(i.tintColor), ToVec4Color(i.borderColor))
+
+	// reserve the whole size (including letterbox)
+	imgui.SetCursorPos(start)
+	imgui.Dummy(size)
 }
 
 type ImageState struct {
@@ -136,6 +214,12 @@ func (i *ImageWithRgbaWidget) Size(width, height float32) *ImageWithRgbaWidget {
 	return i
 }
 
+// Fit sets how the image is fitted into the widget's size.
+func (i *ImageWithRgbaWidget) Fit(fit FitMode) *ImageWithRgbaWidget {
+	i.img.Fit(fit)
+	return i
+}
+
 func (i *ImageWithRgbaWidget) OnClick(cb func()) *ImageWithRgbaWidget {
 	i.img.OnClick(cb)
 	return i
@@ -185,6 +269,12 @@ func (i *ImageWithFileWidget) Size(width, height float32) *ImageWithFileWidget {
 	return i
 }
 
+// Fit sets how the image is fitted into the widget's size.
+func (i *ImageWithFileWidget) Fit(fit FitMode) *ImageWithFileWidget {
+	i.img.Fit(fit)
+	return i
+}
+
 func (i *ImageWithFileWidget) OnClick(cb func()) *ImageWithFileWidget {
 	i.img.OnClick(cb)
 	return i
@@ -263,6 +353,12 @@ func (i *ImageWithURLWidget) Size(width, height float32) *ImageWithURLWidget {
 	return i
 }
 
+// Fit sets how the image is fitted into the widget's size.
+func (i *ImageWithURLWidget) Fit(fit FitMode) *ImageWithURLWidget {
+	i.img.Fit(fit)
+	return i
+}
+
 func (i *ImageWithURLWidget) LayoutForLoading(widgets ...Widget) *ImageWithURLWidget {
 	i.whenLoading = Layout(widgets)
 	return i
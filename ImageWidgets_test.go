@@ -0,0 +1,37 @@
+package giu
+
+import (
+	"image"
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_fitImage(t *testing.T) {
+	box := imgui.Vec2{X: 100, Y: 100}
+	wide := image.Pt(200, 100)
+
+	tests := []struct {
+		name         string
+		fit          FitMode
+		imageSize    image.Point
+		size, offset imgui.Vec2
+		uv0, uv1     imgui.Vec2
+	}{
+		{"stretch", FitStretch, wide, box, imgui.Vec2{}, imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 1}},
+		{"contain", FitContain, wide, imgui.Vec2{X: 100, Y: 50}, imgui.Vec2{X: 0, Y: 25}, imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 1}},
+		{"cover", FitCover, wide, box, imgui.Vec2{}, imgui.Vec2{X: 0.25, Y: 0}, imgui.Vec2{X: 0.75, Y: 1}},
+		{"unknown size", FitCover, image.Point{}, box, imgui.Vec2{}, imgui.Vec2{X: 0, Y: 0}, imgui.Vec2{X: 1, Y: 1}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			size, offset, uv0, uv1 := fitImage(tc.fit, tc.imageSize, box)
+			assert.Equal(t, tc.size, size, "unexpected size")
+			assert.Equal(t, tc.offset, offset, "unexpected offset")
+			assert.Equal(t, tc.uv0, uv0, "unexpected uv0")
+			assert.Equal(t, tc.uv1, uv1, "unexpected uv1")
+		})
+	}
+}
@@ -48,3 +48,19 @@ func (l Layout) Range(rangeFunc func(Widget)) {
 		rangeFunc(w)
 	}
 }
+
+// RangeIndexed ranges over the Layout like Range, but rangeFunc also
+// receives an index of the widget (e.g. to stripe rows).
+// nil widgets are skipped and don't increase the index.
+func (l Layout) RangeIndexed(rangeFunc func(i int, w Widget)) {
+	i := 0
+
+	l.Range(func(w Widget) {
+		if w == nil {
+			return
+		}
+
+		rangeFunc(i, w)
+		i++
+	})
+}
@@ -64,6 +64,36 @@ func Test_Layout_Range(t *testing.T) {
 	}
 }
 
+func Test_Layout_RangeIndexed(t *testing.T) {
+	w1, w2, w3, w4 := &testwidget{}, &testwidget{}, &testwidget{}, &testwidget{}
+	layout := Layout{
+		w1,
+		nil,
+		&splitablewidget{w2, w3},
+		nil,
+		w4,
+	}
+
+	var (
+		indices []int
+		widgets []Widget
+	)
+
+	layout.RangeIndexed(func(i int, w Widget) {
+		indices = append(indices, i)
+		widgets = append(widgets, w)
+	})
+
+	assert.Equal(t, []int{0, 1, 2, 3}, indices, "unexpected indices")
+
+	expected := []Widget{w1, w2, w3, w4}
+	if assert.Len(t, widgets, len(expected), "nil widgets should be skipped") {
+		for i, w := range expected {
+			assert.Same(t, w, widgets[i], "unexpected widget at index %d", i)
+		}
+	}
+}
+
 func Test_Layout_Build(t *testing.T) {
 	tests := []struct {
 		name                        string
@@ -40,3 +40,49 @@ func (l *ListClipperWrapper) Build() {
 
 	clipper.End()
 }
+
+var _ Widget = &LazyVisibleWidget{}
+
+// LazyVisibleWidget builds a widget only when it is visible in the current
+// window (e.g. in a scrolled child); otherwise it only reserves its height.
+// Unlike ListClipper, which requires all items to have the same height,
+// it could be used for items of different heights (each LazyVisible knows
+// its own height), but every lazy item still costs a visibility check per frame.
+type LazyVisibleWidget struct {
+	height float32
+	build  func() Widget
+}
+
+// LazyVisible creates a new LazyVisibleWidget. Height should be the height
+// of the widget returned by build.
+func LazyVisible(height float32, build func() Widget) *LazyVisibleWidget {
+	return &LazyVisibleWidget{
+		height: height,
+		build:  build,
+	}
+}
+
+// Build implements Widget interface.
+func (l *LazyVisibleWidget) Build() {
+	pos := imgui.CursorScreenPos()
+	windowPos, windowSize := imgui.WindowPos(), imgui.WindowSize()
+	isVisible := pos.Y+l.height >= windowPos.Y && pos.Y <= windowPos.Y+windowSize.Y
+
+	if !isVisible || l.build == nil {
+		imgui.Dummy(imgui.Vec2{X: 0, Y: l.height})
+		return
+	}
+
+	imgui.BeginGroup()
+
+	if w := l.build(); w != nil {
+		w.Build()
+	}
+
+	// keep reserved height even if the widget is smaller
+	if height := imgui.CursorScreenPos().Y - pos.Y; height < l.height {
+		imgui.Dummy(imgui.Vec2{X: 0, Y: l.height - height})
+	}
+
+	imgui.EndGroup()
+}
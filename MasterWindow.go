@@ -1,8 +1,10 @@
 package giu
 
 import (
+	"fmt"
 	"image"
 	"image/color"
+	"os"
 	"runtime"
 	"time"
 
@@ -42,6 +44,14 @@ type MasterWindow struct {
 	context    *imgui.Context
 	io         *imgui.IO
 	updateFunc func()
+	// toggled by the debug shortcut (see DebugShortcut)
+	showDemoWindow bool
+	// maximal number of frames per second (0 means platform's default)
+	fpsLimit int
+	// theme applied to the whole application (see WatchTheme)
+	theme *StyleSetter
+	// closed to stop watching the theme file (nil if it isn't watched)
+	stopThemeWatch chan struct{}
 }
 
 // NewMasterWindow creates a new master window and initializes GLFW.
@@ -190,6 +200,7 @@ func (w *MasterWindow) render() {
 	Context.invalidAllState()
 
 	rebuildFontAtlas()
+	imgui.CurrentIO().SetFontGlobalScale(globalFontScale)
 
 	p := w.platform
 	r := w.renderer
@@ -198,7 +209,17 @@ func (w *MasterWindow) render() {
 	r.PreRender(w.clearColor)
 
 	imgui.NewFrame()
-	w.updateFunc()
+
+	if w.theme != nil {
+		w.theme.To(Custom(w.updateFunc)).Build()
+	} else {
+		w.updateFunc()
+	}
+
+	if w.showDemoWindow {
+		ShowDemoWindow(&w.showDemoWindow)
+	}
+
 	imgui.Render()
 
 	r.Render(p.DisplaySize(), p.FramebufferSize(), imgui.RenderedDrawData())
@@ -211,7 +232,8 @@ func (w *MasterWindow) render() {
 func (w *MasterWindow) run() {
 	p := w.platform
 
-	ticker := time.NewTicker(time.Second / time.Duration(p.GetTPS()))
+	fps := w.getFPSLimit()
+	ticker := time.NewTicker(time.Second / time.Duration(fps))
 	shouldQuit := false
 	for !shouldQuit {
 		mainthread.Call(func() {
@@ -221,8 +243,114 @@ func (w *MasterWindow) run() {
 			shouldQuit = p.ShouldStop()
 		})
 
+		// fps limit could be changed in the frame
+		if newFPS := w.getFPSLimit(); newFPS != fps {
+			fps = newFPS
+			ticker.Reset(time.Second / time.Duration(fps))
+		}
+
 		<-ticker.C
 	}
+
+	ticker.Stop()
+}
+
+func (w *MasterWindow) getFPSLimit() int {
+	if w.fpsLimit > 0 {
+		return w.fpsLimit
+	}
+
+	return w.platform.GetTPS()
+}
+
+// SetFPSLimit sets maximal number of frames rendered per second
+// (fps <= 0 restores the platform's default).
+// Independently from the limit, when there is no user input, frames
+// are rendered only occasionally; use Update to render them on demand
+// (e.g. while an animation is running).
+// It should be called in the loop function or before (*MasterWindow).Run.
+func (w *MasterWindow) SetFPSLimit(fps int) {
+	w.fpsLimit = fps
+}
+
+// WatchTheme loads a theme file and applies it to the whole application.
+// The file is a JSON with "colors" (names of StyleColorIDs mapped to "#RRGGBBAA"
+// strings) and "styles" (names of StyleVarIDs mapped to numbers or {"x": ..., "y": ...}).
+// The file is watched for changes, so the theme could be edited live:
+// when it changes, the new theme is applied; if it isn't valid,
+// a warning is printed and the previous theme is kept.
+// The file is watched until the window is closed or WatchTheme is called again.
+// It returns an error if the file can't be loaded initially.
+func (w *MasterWindow) WatchTheme(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("WatchTheme: %w", err)
+	}
+
+	theme, err := loadThemeFile(path)
+	if err != nil {
+		return fmt.Errorf("WatchTheme: %w", err)
+	}
+
+	w.theme = theme
+
+	w.stopWatchingTheme()
+	w.stopThemeWatch = make(chan struct{})
+
+	go w.watchTheme(path, info.ModTime(), w.stopThemeWatch)
+
+	return nil
+}
+
+// stopWatchingTheme stops watching of the theme file (if any).
+func (w *MasterWindow) stopWatchingTheme() {
+	if w.stopThemeWatch != nil {
+		close(w.stopThemeWatch)
+		w.stopThemeWatch = nil
+	}
+}
+
+// themeWatchInterval is how often the theme file is checked for changes.
+const themeWatchInterval = 500 * time.Millisecond
+
+func (w *MasterWindow) watchTheme(path string, lastModTime time.Time, stop <-chan struct{}) {
+	var pendingModTime time.Time
+
+	ticker := time.NewTicker(themeWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Equal(lastModTime) {
+			continue
+		}
+
+		// debounce: editors often save a file in several writes,
+		// so wait until the file is not modified for an interval.
+		if !info.ModTime().Equal(pendingModTime) {
+			pendingModTime = info.ModTime()
+			continue
+		}
+
+		lastModTime = pendingModTime
+
+		theme, err := loadThemeFile(path)
+		if err != nil {
+			fmt.Printf("[Warning]WatchTheme: %v; previous theme is kept.\n", err)
+			continue
+		}
+
+		mainthread.CallNonBlock(func() {
+			w.theme = theme
+			Update()
+		})
+	}
 }
 
 // GetSize return size of master window.
@@ -298,6 +426,8 @@ func (w *MasterWindow) Run(loopFunc func()) {
 
 		Context.isAlive = false
 
+		w.stopWatchingTheme()
+
 		mainthread.Call(func() {
 			w.renderer.Dispose()
 			w.platform.Dispose()
@@ -323,6 +453,18 @@ func (w *MasterWindow) RegisterKeyboardShortcuts(s ...WindowShortcut) *MasterWin
 	return w
 }
 
+// DebugShortcut registers a global shortcut, which toggles imgui's demo window
+// (see ShowDemoWindow) displayed over the app's windows.
+func (w *MasterWindow) DebugShortcut(key Key, modifier Modifier) *MasterWindow {
+	return w.RegisterKeyboardShortcuts(WindowShortcut{
+		Key:      key,
+		Modifier: modifier,
+		Callback: func() {
+			w.showDemoWindow = !w.showDemoWindow
+		},
+	})
+}
+
 // SetIcon sets the icon of the specified window. If passed an array of candidate images,
 // those of or closest to the sizes desired by the system are selected. If no images are
 // specified, the window reverts to its default icon.
@@ -86,3 +86,152 @@ func (p *PopupModalWidget) Build() {
 		imgui.EndPopup()
 	}
 }
+
+var _ Disposable = &confirmActionState{}
+
+type confirmActionState struct {
+	isOpen bool
+}
+
+// Dispose implements Disposable interface.
+func (s *confirmActionState) Dispose() {
+	// noop
+}
+
+var _ Widget = &ConfirmActionWidget{}
+
+// ConfirmActionWidget wraps a trigger widget (e.g. a "Delete" button).
+// When the trigger gets clicked, a modal asking user for confirmation
+// is shown and OnConfirm callback is called if user confirms.
+// OnClick callback of a button (or selectable) trigger is called
+// only after the confirmation as well (before OnConfirm).
+type ConfirmActionWidget struct {
+	id        string
+	message   string
+	trigger   Widget
+	onConfirm func()
+}
+
+// ConfirmAction creates a new ConfirmActionWidget.
+func ConfirmAction(message string) *ConfirmActionWidget {
+	return &ConfirmActionWidget{
+		id:      GenAutoID("ConfirmAction"),
+		message: tStr(message),
+	}
+}
+
+// ID allows to manually set widget's id.
+func (c *ConfirmActionWidget) ID(id string) *ConfirmActionWidget {
+	c.id = id
+	return c
+}
+
+// OnConfirm sets callback called when user confirms the action.
+func (c *ConfirmActionWidget) OnConfirm(onConfirm func()) *ConfirmActionWidget {
+	c.onConfirm = onConfirm
+	return c
+}
+
+// To sets widget, which triggers confirmation when clicked.
+func (c *ConfirmActionWidget) To(trigger Widget) *ConfirmActionWidget {
+	c.trigger = trigger
+	return c
+}
+
+// Build implements Widget interface.
+func (c *ConfirmActionWidget) Build() {
+	if c.trigger == nil {
+		return
+	}
+
+	var state *confirmActionState
+	if s := Context.GetState(c.id); s == nil {
+		state = &confirmActionState{}
+		Context.SetState(c.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*confirmActionState)
+		Assert(isOk, "ConfirmActionWidget", "Build", "unexpected state recovered")
+	}
+
+	trigger, onClick := withoutOnClick(c.trigger)
+	trigger.Build()
+
+	popupName := "Confirm##" + c.id
+
+	if IsItemClicked(MouseButtonLeft) {
+		state.isOpen = true
+
+		imgui.OpenPopup(popupName)
+	}
+
+	PopupModal(popupName).IsOpen(&state.isOpen).Flags(WindowFlagsNoResize|WindowFlagsAlwaysAutoResize).Layout(
+		Label(c.message),
+		Row(
+			Button("Yes").OnClick(func() {
+				CloseCurrentPopup()
+				state.isOpen = false
+
+				if onClick != nil {
+					onClick()
+				}
+
+				if c.onConfirm != nil {
+					c.onConfirm()
+				}
+			}),
+			Button("No").OnClick(func() {
+				CloseCurrentPopup()
+				state.isOpen = false
+			}),
+		),
+	).Build()
+}
+
+// withoutOnClick returns a copy of the trigger without its OnClick callback
+// (which is returned as well), so that it isn't called when the trigger is clicked.
+// Other widgets are returned unchanged.
+func withoutOnClick(trigger Widget) (Widget, func()) {
+	switch w := trigger.(type) {
+	case *ButtonWidget:
+		c := *w
+		c.onClick = nil
+
+		return &c, w.onClick
+	case *SmallButtonWidget:
+		c := *w
+		c.onClick = nil
+
+		return &c, w.onClick
+	case *ArrowButtonWidget:
+		c := *w
+		c.onClick = nil
+
+		return &c, w.onClick
+	case *InvisibleButtonWidget:
+		c := *w
+		c.onClick = nil
+
+		return &c, w.onClick
+	case *ImageButtonWidget:
+		c := *w
+		c.onClick = nil
+
+		return &c, w.onClick
+	case *ImageButtonWithRgbaWidget:
+		button := *w.ImageButtonWidget
+		button.onClick = nil
+
+		c := *w
+		c.ImageButtonWidget = &button
+
+		return &c, w.onClick
+	case *SelectableWidget:
+		c := *w
+		c.onClick = nil
+
+		return &c, w.onClick
+	}
+
+	return trigger, nil
+}
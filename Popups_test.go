@@ -0,0 +1,64 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConfirmActionWidget_TriggerOnClick(t *testing.T) {
+	io := newTestContext(t)
+
+	clicks, confirms := 0, 0
+
+	var buttonMin, buttonMax imgui.Vec2
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.Begin("confirm action")
+
+		ConfirmAction("Are you sure?").
+			ID("confirm").
+			OnConfirm(func() { confirms++ }).
+			To(Button("Delete").OnClick(func() { clicks++ })).
+			Build()
+
+		// the closed popup doesn't add an item
+		buttonMin, buttonMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+	frame()
+
+	clickAt(io, imgui.Vec2{X: (buttonMin.X + buttonMax.X) / 2, Y: (buttonMin.Y + buttonMax.Y) / 2}, frame)
+
+	state, isOk := Context.GetState("confirm").(*confirmActionState)
+	assert.True(t, isOk, "unexpected state")
+	assert.True(t, state.isOpen, "clicking the trigger should open the confirmation")
+	assert.Equal(t, 0, clicks, "trigger's OnClick shouldn't be called before confirmation")
+	assert.Equal(t, 0, confirms, "OnConfirm shouldn't be called before confirmation")
+}
+
+func Test_withoutOnClick(t *testing.T) {
+	clicks := 0
+	button := Button("Delete").OnClick(func() { clicks++ })
+
+	trigger, onClick := withoutOnClick(button)
+
+	assert.NotNil(t, onClick, "OnClick should be returned")
+	assert.Nil(t, trigger.(*ButtonWidget).onClick, "trigger shouldn't have OnClick")
+	assert.NotNil(t, button.onClick, "original button shouldn't be changed")
+
+	onClick()
+	assert.Equal(t, 1, clicks, "returned OnClick should be button's one")
+
+	label := Label("label")
+	trigger, onClick = withoutOnClick(label)
+
+	assert.Equal(t, Widget(label), trigger, "other widgets shouldn't be changed")
+	assert.Nil(t, onClick, "other widgets have no OnClick")
+}
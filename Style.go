@@ -1,6 +1,7 @@
 package giu
 
 import (
+	"image"
 	"image/color"
 
 	"github.com/AllenDang/imgui-go"
@@ -33,6 +34,13 @@ func PushStyleColor(id StyleColorID, col color.Color) {
 	imgui.PushStyleColor(imgui.StyleColorID(id), ToVec4Color(col))
 }
 
+// PushStyleColorVec4 does similar to PushStyleColor, but takes the color's
+// channels (in range 0-1) as they are, without conversion from color.Color.
+// NOTE: don't forget to call PopStyleColor()!
+func PushStyleColorVec4(id StyleColorID, r, g, b, a float32) {
+	imgui.PushStyleColor(imgui.StyleColorID(id), imgui.Vec4{X: r, Y: g, Z: b, W: a})
+}
+
 // PushColorText calls PushStyleColor(StyleColorText,...)
 // NOTE: don't forget to call PopStyleColor()!
 func PushColorText(col color.Color) {
@@ -100,6 +108,27 @@ func PushSelectableTextAlign(width, height float32) {
 	imgui.PushStyleVarVec2(imgui.StyleVarSelectableTextAlign, imgui.Vec2{X: width, Y: height})
 }
 
+// PushStyleVar pushes the style var. Vec2 vars (see StyleVarID.IsVec2)
+// take two values (x and y) and float vars take one value.
+// It panics if number of values doesn't match.
+// NOTE: don't forget to call PopStyleVar()!
+func PushStyleVar(id StyleVarID, v ...float32) {
+	if id.IsVec2() {
+		Assert(len(v) == 2, "", "PushStyleVar", "style var %d is a vec2 and takes 2 values (got %d)", id, len(v))
+		imgui.PushStyleVarVec2(imgui.StyleVarID(id), imgui.Vec2{X: v[0], Y: v[1]})
+
+		return
+	}
+
+	Assert(len(v) == 1, "", "PushStyleVar", "style var %d is a float and takes 1 value (got %d)", id, len(v))
+	imgui.PushStyleVarFloat(imgui.StyleVarID(id), v[0])
+}
+
+// PopStyleVar pops a style var pushed by PushStyleVar (same as PopStyle).
+func PopStyleVar() {
+	imgui.PopStyleVar()
+}
+
 // PopStyle should be called to stop applying style.
 // It should be called as much times, as you Called PushStyle...
 // NOTE: If you don't call PopStyle imgui will panic.
@@ -209,6 +238,39 @@ func GetFramePadding() (x, y float32) {
 	return vec2.X, vec2.Y
 }
 
+// GetStyleColor returns current value of the style color
+// (e.g. to derive a hover tint from the button's color).
+func GetStyleColor(id StyleColorID) color.Color {
+	return Vec4ToRGBA(imgui.CurrentStyle().GetColor(imgui.StyleColorID(id)))
+}
+
+// GetStyleVarVec2 returns current value of the vec2 style var.
+// NOTE: imgui-go exposes only StyleVarWindowPadding, StyleVarFramePadding,
+// StyleVarItemSpacing and StyleVarItemInnerSpacing (and none of the float
+// vars); it panics for other vars.
+func GetStyleVarVec2(id StyleVarID) (x, y float32) {
+	Assert(id.IsVec2(), "", "GetStyleVarVec2", "style var %d is a float", id)
+
+	style := imgui.CurrentStyle()
+
+	var value imgui.Vec2
+
+	switch id {
+	case StyleVarWindowPadding:
+		value = style.WindowPadding()
+	case StyleVarFramePadding:
+		value = style.FramePadding()
+	case StyleVarItemSpacing:
+		value = style.ItemSpacing()
+	case StyleVarItemInnerSpacing:
+		value = style.ItemInnerSpacing()
+	default:
+		fatal("", "GetStyleVarVec2", "reading style var %d isn't supported by imgui-go", id)
+	}
+
+	return value.X, value.Y
+}
+
 // StyleColorID identifies a color in the UI style.
 type StyleColorID int
 
@@ -355,13 +417,51 @@ type StyleSetter struct {
 	font     *FontInfo
 	disabled bool
 	layout   Layout
+
+	autoContrast      bool
+	contrastThreshold float64
+
+	// id is used to keep the layout's rect (set if stateful colors are used)
+	id             string
+	statefulColors map[StyleColorID]statefulColor
+}
+
+// statefulColor holds colors used depending on the mouse state (see SetColorStateful).
+type statefulColor struct {
+	normal, hovered, active color.Color
 }
 
+var _ Disposable = &styleSetterState{}
+
+type styleSetterState struct {
+	// rect of the layout in the previous frame
+	min, max imgui.Vec2
+}
+
+// Dispose implements Disposable interface.
+func (s *styleSetterState) Dispose() {
+	// noop
+}
+
+// defaultContrastThreshold is a WCAG's minimum contrast ratio for a normal text.
+const defaultContrastThreshold = 4.5
+
+var (
+	// contrastForegrounds are text colors corrected by StyleSetter's AutoContrast.
+	contrastForegrounds = []StyleColorID{StyleColorText, StyleColorTextDisabled}
+	// contrastBackgrounds are colors the text is displayed on.
+	contrastBackgrounds = []StyleColorID{
+		StyleColorWindowBg, StyleColorChildBg, StyleColorPopupBg,
+		StyleColorFrameBg, StyleColorButton, StyleColorHeader,
+	}
+)
+
 // Style initializes a style setter (see examples/setstyle).
 func Style() *StyleSetter {
 	var ss StyleSetter
 	ss.colors = make(map[StyleColorID]color.Color)
 	ss.styles = make(map[StyleVarID]interface{})
+	ss.contrastThreshold = defaultContrastThreshold
 
 	return &ss
 }
@@ -372,12 +472,122 @@ func (ss *StyleSetter) SetColor(colorID StyleColorID, col color.Color) *StyleSet
 	return ss
 }
 
+// SetButtonColors sets StyleColorButton, StyleColorButtonHovered and StyleColorButtonActive.
+func (ss *StyleSetter) SetButtonColors(normal, hovered, active color.Color) *StyleSetter {
+	return ss.setColorTrio(StyleColorButton, StyleColorButtonHovered, StyleColorButtonActive, normal, hovered, active)
+}
+
+// SetFrameColors sets StyleColorFrameBg, StyleColorFrameBgHovered and StyleColorFrameBgActive
+// (backgrounds of checkboxes, radio buttons, sliders, inputs etc.).
+func (ss *StyleSetter) SetFrameColors(normal, hovered, active color.Color) *StyleSetter {
+	return ss.setColorTrio(StyleColorFrameBg, StyleColorFrameBgHovered, StyleColorFrameBgActive, normal, hovered, active)
+}
+
+// SetHeaderColors sets StyleColorHeader, StyleColorHeaderHovered and StyleColorHeaderActive
+// (used by Selectable, TreeNode and MenuItem).
+func (ss *StyleSetter) SetHeaderColors(normal, hovered, active color.Color) *StyleSetter {
+	return ss.setColorTrio(StyleColorHeader, StyleColorHeaderHovered, StyleColorHeaderActive, normal, hovered, active)
+}
+
+// SetTabColors sets StyleColorTab, StyleColorTabHovered and StyleColorTabActive.
+func (ss *StyleSetter) SetTabColors(normal, hovered, active color.Color) *StyleSetter {
+	return ss.setColorTrio(StyleColorTab, StyleColorTabHovered, StyleColorTabActive, normal, hovered, active)
+}
+
+func (ss *StyleSetter) setColorTrio(normalID, hoveredID, activeID StyleColorID, normal, hovered, active color.Color) *StyleSetter {
+	return ss.SetColor(normalID, normal).SetColor(hoveredID, hovered).SetColor(activeID, active)
+}
+
+// vec4Color is a color set by SetColorVec4. It is pushed to imgui
+// as is, without conversion to color.Color's integer components.
+type vec4Color imgui.Vec4
+
+// RGBA implements color.Color.
+func (c vec4Color) RGBA() (r, g, b, a uint32) {
+	const mask = 0xffff
+
+	return uint32(c.X * mask), uint32(c.Y * mask), uint32(c.Z * mask), uint32(c.W * mask)
+}
+
+// SetColorVec4 sets colorID's color given by float components (0-1).
+// Unlike SetColor, the components are passed to imgui without any conversion,
+// so they are preserved exactly.
+func (ss *StyleSetter) SetColorVec4(colorID StyleColorID, r, g, b, a float32) *StyleSetter {
+	ss.colors[colorID] = vec4Color{X: r, Y: g, Z: b, W: a}
+	return ss
+}
+
+// SetColorStateful sets colorID's color depending on the mouse state:
+// hovered is used when the mouse is over the layout and active when
+// the left mouse button is also down.
+// NOTE: the whole layout region (as of the previous frame) is hit-tested,
+// not the individual items. It makes possible e.g. a hoverable "card":
+//
+//	Style().SetColorStateful(StyleColorChildBg, normal, hovered, active).To(Child().Layout(...))
+func (ss *StyleSetter) SetColorStateful(colorID StyleColorID, normal, hovered, active color.Color) *StyleSetter {
+	if ss.statefulColors == nil {
+		ss.statefulColors = make(map[StyleColorID]statefulColor)
+	}
+
+	if ss.id == "" {
+		ss.id = GenAutoID("StyleSetter")
+	}
+
+	ss.statefulColors[colorID] = statefulColor{normal, hovered, active}
+
+	return ss
+}
+
+// ID allows to manually set setter's id (used by SetColorStateful).
+func (ss *StyleSetter) ID(id string) *StyleSetter {
+	ss.id = id
+	return ss
+}
+
+// applyStatefulColors picks stateful colors depending on whether the layout
+// (according to its rect in the previous frame) is hovered.
+func (ss *StyleSetter) applyStatefulColors() *styleSetterState {
+	var state *styleSetterState
+	if s := Context.GetState(ss.id); s == nil {
+		state = &styleSetterState{}
+		Context.SetState(ss.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*styleSetterState)
+		Assert(isOk, "StyleSetter", "Build", "got unexpected type of widget's state")
+	}
+
+	mousePos := imgui.MousePos()
+	isHovered := IsWindowHovered(HoveredFlagsAllowWhenBlockedByActiveItem|HoveredFlagsChildWindows) &&
+		mousePos.X >= state.min.X && mousePos.Y >= state.min.Y &&
+		mousePos.X < state.max.X && mousePos.Y < state.max.Y
+	isActive := isHovered && IsMouseDown(MouseButtonLeft)
+
+	for id, c := range ss.statefulColors {
+		switch {
+		case isActive:
+			ss.colors[id] = c.active
+		case isHovered:
+			ss.colors[id] = c.hovered
+		default:
+			ss.colors[id] = c.normal
+		}
+	}
+
+	return state
+}
+
 // SetStyle sets styleVarID to width and height.
 func (ss *StyleSetter) SetStyle(varID StyleVarID, width, height float32) *StyleSetter {
 	ss.styles[varID] = imgui.Vec2{X: width, Y: height}
 	return ss
 }
 
+// SetStylePt sets styleVarID to pt (same as SetStyle(varID, pt.X, pt.Y)).
+func (ss *StyleSetter) SetStylePt(varID StyleVarID, pt image.Point) *StyleSetter {
+	return ss.SetStyle(varID, float32(pt.X), float32(pt.Y))
+}
+
 // SetStyleFloat sets styleVarID to float value.
 // NOTE: for float typed values see above in comments over
 // StyleVarID's comments.
@@ -402,9 +612,7 @@ func (ss *StyleSetter) SetFontSize(size float32) *StyleSetter {
 
 	font.size = size
 
-	extraFonts = append(extraFonts, font)
-
-	ss.font = &font
+	ss.font = addExtraFont(font)
 
 	return ss
 }
@@ -415,6 +623,54 @@ func (ss *StyleSetter) SetDisabled(d bool) *StyleSetter {
 	return ss
 }
 
+// SetDisabledAlpha sets alpha multiplier applied to disabled items
+// (see SetDisabled), e.g. 0.8 for barely faded items or 0.3 for heavily faded.
+func (ss *StyleSetter) SetDisabledAlpha(alpha float32) *StyleSetter {
+	return ss.SetStyleFloat(StyleVarDisabledAlpha, alpha)
+}
+
+// AutoContrast enables automatic correction of text colors: if contrast of
+// a text color set by the setter and a background is below the threshold
+// (see ContrastThreshold), the text color gets moved towards black or white.
+// Backgrounds set by the setter are checked (if none, the current window background is).
+// NOTE: only colors managed by the setter itself are adjusted.
+func (ss *StyleSetter) AutoContrast(enabled bool) *StyleSetter {
+	ss.autoContrast = enabled
+	return ss
+}
+
+// ContrastThreshold sets minimum contrast ratio used by AutoContrast (4.5 by default).
+func (ss *StyleSetter) ContrastThreshold(threshold float64) *StyleSetter {
+	ss.contrastThreshold = threshold
+	return ss
+}
+
+// correctContrast applies AutoContrast to the setter's colors.
+func (ss *StyleSetter) correctContrast() {
+	var backgrounds []color.Color
+
+	for _, id := range contrastBackgrounds {
+		if bg, ok := ss.colors[id]; ok {
+			backgrounds = append(backgrounds, bg)
+		}
+	}
+
+	if len(backgrounds) == 0 {
+		backgrounds = append(backgrounds, Vec4ToColor(imgui.CurrentStyle().GetColor(imgui.StyleColorWindowBg)))
+	}
+
+	for _, id := range contrastForegrounds {
+		fg, ok := ss.colors[id]
+		if !ok {
+			continue
+		}
+
+		// all the backgrounds are checked at once; fixing the color
+		// for one of them could break its contrast with another one
+		ss.colors[id] = ensureContrast(fg, backgrounds, ss.contrastThreshold)
+	}
+}
+
 // To allows to specify a layout, StyleSetter should apply style for.
 func (ss *StyleSetter) To(widgets ...Widget) *StyleSetter {
 	ss.layout = widgets
@@ -427,10 +683,27 @@ func (ss *StyleSetter) Build() {
 		return
 	}
 
+	var state *styleSetterState
+	if len(ss.statefulColors) > 0 {
+		state = ss.applyStatefulColors()
+	}
+
+	if ss.autoContrast {
+		ss.correctContrast()
+	}
+
 	for k, v := range ss.colors {
-		imgui.PushStyleColor(imgui.StyleColorID(k), ToVec4Color(v))
+		col, ok := v.(vec4Color)
+		if !ok {
+			col = vec4Color(ToVec4Color(v))
+		}
+
+		imgui.PushStyleColor(imgui.StyleColorID(k), imgui.Vec4(col))
 	}
 
+	// pops are deferred to keep the style stack balanced even if the layout panics
+	defer imgui.PopStyleColorV(len(ss.colors))
+
 	for k, v := range ss.styles {
 		if k.IsVec2() {
 			var value imgui.Vec2
@@ -455,21 +728,23 @@ func (ss *StyleSetter) Build() {
 		}
 	}
 
-	isFontPushed := false
-	if ss.font != nil {
-		isFontPushed = PushFont(ss.font)
+	defer imgui.PopStyleVarV(len(ss.styles))
+
+	if ss.font != nil && PushFont(ss.font) {
+		defer PopFont()
 	}
 
 	imgui.BeginDisabled(ss.disabled)
-
-	ss.layout.Build()
-
-	imgui.EndDisabled()
-
-	if isFontPushed {
-		PopFont()
+	defer imgui.EndDisabled()
+
+	if state != nil {
+		// group gives the rect of the whole layout
+		imgui.BeginGroup()
+		defer func() {
+			imgui.EndGroup()
+			state.min, state.max = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		}()
 	}
 
-	imgui.PopStyleColorV(len(ss.colors))
-	imgui.PopStyleVarV(len(ss.styles))
+	ss.layout.Build()
 }
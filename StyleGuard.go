@@ -0,0 +1,131 @@
+package giu
+
+import (
+	"image/color"
+	"io"
+
+	"github.com/AllenDang/imgui-go"
+)
+
+var _ io.Closer = &StyleGuard{}
+
+type styleGuardColor struct {
+	id    StyleColorID
+	value imgui.Vec4
+}
+
+type styleGuardVar struct {
+	id    StyleVarID
+	value imgui.Vec2
+}
+
+// StyleGuard pushes style colors, vars and font and pops exactly
+// what it has pushed, e.g.:
+//
+//	guard := giu.BeginStyle().
+//		Color(giu.StyleColorText, colornames.Red).
+//		Var(giu.StyleVarItemSpacing, 0, 0).
+//		Push()
+//	defer guard.End()
+//
+// See also StyleSetter.
+type StyleGuard struct {
+	colors []styleGuardColor
+	vars   []styleGuardVar
+	font   *FontInfo
+
+	isPushed bool
+	isEnded  bool
+
+	// numbers of pushed items
+	pushedColors, pushedVars int
+	isFontPushed             bool
+}
+
+// BeginStyle creates a new StyleGuard.
+func BeginStyle() *StyleGuard {
+	return &StyleGuard{}
+}
+
+// Color adds a style color to be pushed.
+func (g *StyleGuard) Color(id StyleColorID, col color.Color) *StyleGuard {
+	return g.ColorVec4(id, ToVec4Color(col))
+}
+
+// ColorVec4 does similar to Color, but takes the color as imgui.Vec4 (without conversion).
+func (g *StyleGuard) ColorVec4(id StyleColorID, col imgui.Vec4) *StyleGuard {
+	g.colors = append(g.colors, styleGuardColor{id: id, value: col})
+	return g
+}
+
+// Var adds a style var to be pushed (see StyleSetter.SetStyle).
+func (g *StyleGuard) Var(id StyleVarID, width, height float32) *StyleGuard {
+	g.vars = append(g.vars, styleGuardVar{id: id, value: imgui.Vec2{X: width, Y: height}})
+	return g
+}
+
+// VarFloat adds a float style var to be pushed (see StyleSetter.SetStyleFloat).
+func (g *StyleGuard) VarFloat(id StyleVarID, value float32) *StyleGuard {
+	return g.Var(id, value, value)
+}
+
+// Font sets font to be pushed.
+func (g *StyleGuard) Font(font *FontInfo) *StyleGuard {
+	g.font = font
+	return g
+}
+
+// Push pushes the colors, vars and font. It could be called only once.
+// NOTE: End has to be called (e.g. deferred)!
+func (g *StyleGuard) Push() *StyleGuard {
+	Assert(!g.isPushed, "StyleGuard", "Push", "the style has been already pushed")
+	g.isPushed = true
+
+	for _, c := range g.colors {
+		imgui.PushStyleColor(imgui.StyleColorID(c.id), c.value)
+		g.pushedColors++
+	}
+
+	for _, v := range g.vars {
+		if v.id.IsVec2() {
+			imgui.PushStyleVarVec2(imgui.StyleVarID(v.id), v.value)
+		} else {
+			imgui.PushStyleVarFloat(imgui.StyleVarID(v.id), v.value.X)
+		}
+
+		g.pushedVars++
+	}
+
+	if g.font != nil {
+		g.isFontPushed = PushFont(g.font)
+	}
+
+	return g
+}
+
+// End pops everything pushed by Push. Subsequent calls do nothing.
+func (g *StyleGuard) End() {
+	if g.isEnded {
+		return
+	}
+
+	g.isEnded = true
+
+	if g.isFontPushed {
+		PopFont()
+	}
+
+	if g.pushedVars > 0 {
+		imgui.PopStyleVarV(g.pushedVars)
+	}
+
+	if g.pushedColors > 0 {
+		imgui.PopStyleColorV(g.pushedColors)
+	}
+}
+
+// Close implements io.Closer (it calls End).
+func (g *StyleGuard) Close() error {
+	g.End()
+	return nil
+}
@@ -0,0 +1,52 @@
+package giu
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StyleGuard(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("style guard")
+
+	style := imgui.CurrentStyle()
+	textColor, buttonColor := style.GetColor(imgui.StyleColorText), style.GetColor(imgui.StyleColorButton)
+	itemSpacing := style.ItemSpacing()
+
+	guard := BeginStyle().
+		Color(StyleColorText, color.RGBA{R: 255, A: 255}).
+		ColorVec4(StyleColorButton, imgui.Vec4{X: 0.1, Y: 0.2, Z: 0.3, W: 0.4}).
+		Var(StyleVarItemSpacing, 3, 4).
+		VarFloat(StyleVarFrameRounding, 5).
+		Push()
+
+	assert.Equal(t, imgui.Vec4{X: 1, Y: 0, Z: 0, W: 1}, style.GetColor(imgui.StyleColorText), "color wasn't pushed")
+	assert.Equal(t, imgui.Vec4{X: 0.1, Y: 0.2, Z: 0.3, W: 0.4}, style.GetColor(imgui.StyleColorButton), "color wasn't pushed")
+	assert.Equal(t, imgui.Vec2{X: 3, Y: 4}, style.ItemSpacing(), "style var wasn't pushed")
+	assert.Panics(t, func() { guard.Push() }, "style pushed twice")
+
+	// outer style isn't popped by the guard
+	PushStyleColor(StyleColorText, color.RGBA{G: 255, A: 255})
+
+	inner := BeginStyle().Color(StyleColorText, color.RGBA{B: 255, A: 255}).Push()
+	assert.NoError(t, inner.Close())
+	inner.End()
+	assert.Equal(t, imgui.Vec4{X: 0, Y: 1, Z: 0, W: 1}, style.GetColor(imgui.StyleColorText), "guard popped more than it pushed")
+
+	PopStyleColor()
+
+	guard.End()
+	guard.End()
+
+	assert.Equal(t, textColor, style.GetColor(imgui.StyleColorText), "color wasn't popped")
+	assert.Equal(t, buttonColor, style.GetColor(imgui.StyleColorButton), "color wasn't popped")
+	assert.Equal(t, itemSpacing, style.ItemSpacing(), "style var wasn't popped")
+
+	imgui.End()
+	imgui.Render()
+}
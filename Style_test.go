@@ -0,0 +1,233 @@
+package giu
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StyleSetter_SetColorVec4(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("color vec4")
+
+	var pushed imgui.Vec4
+
+	Style().
+		SetColorVec4(StyleColorText, 0.1, 0.2, 0.3, 0.137).
+		To(Custom(func() {
+			pushed = imgui.CurrentStyle().GetColor(imgui.StyleColorText)
+		})).
+		Build()
+
+	imgui.End()
+	imgui.Render()
+
+	assert.Equal(t, imgui.Vec4{X: 0.1, Y: 0.2, Z: 0.3, W: 0.137}, pushed)
+}
+
+func Test_StyleSetter_panickingLayout(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("panicking layout")
+
+	style := imgui.CurrentStyle()
+	textColor, itemSpacing := style.GetColor(imgui.StyleColorText), style.ItemSpacing()
+
+	assert.Panics(t, func() {
+		Style().
+			SetColor(StyleColorText, color.RGBA{R: 255, A: 255}).
+			SetStyle(StyleVarItemSpacing, 20, 20).
+			SetDisabled(true).
+			To(Custom(func() {
+				panic("widget failed")
+			})).
+			Build()
+	})
+
+	assert.Equal(t, textColor, style.GetColor(imgui.StyleColorText), "style color wasn't popped")
+	assert.Equal(t, itemSpacing, style.ItemSpacing(), "style var wasn't popped")
+
+	imgui.End()
+	imgui.Render()
+}
+
+func Test_StyleSetter_colorTrios(t *testing.T) {
+	normal := color.RGBA{R: 1, A: 255}
+	hovered := color.RGBA{R: 2, A: 255}
+	active := color.RGBA{R: 3, A: 255}
+
+	tests := []struct {
+		name string
+		set  func(ss *StyleSetter, normal, hovered, active color.Color) *StyleSetter
+		ids  [3]StyleColorID
+	}{
+		{"button", (*StyleSetter).SetButtonColors, [3]StyleColorID{StyleColorButton, StyleColorButtonHovered, StyleColorButtonActive}},
+		{"frame", (*StyleSetter).SetFrameColors, [3]StyleColorID{StyleColorFrameBg, StyleColorFrameBgHovered, StyleColorFrameBgActive}},
+		{"header", (*StyleSetter).SetHeaderColors, [3]StyleColorID{StyleColorHeader, StyleColorHeaderHovered, StyleColorHeaderActive}},
+		{"tab", (*StyleSetter).SetTabColors, [3]StyleColorID{StyleColorTab, StyleColorTabHovered, StyleColorTabActive}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ss := tc.set(Style(), normal, hovered, active)
+			assert.Equal(t, map[StyleColorID]color.Color{
+				tc.ids[0]: normal,
+				tc.ids[1]: hovered,
+				tc.ids[2]: active,
+			}, ss.colors)
+		})
+	}
+}
+
+func Test_StyleSetter_SetStylePt(t *testing.T) {
+	tests := []struct {
+		name string
+		pt   image.Point
+	}{
+		{"zero", image.Pt(0, 0)},
+		{"positive", image.Pt(8, 4)},
+		{"negative", image.Pt(-3, 12)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expected := Style().SetStyle(StyleVarItemSpacing, float32(tc.pt.X), float32(tc.pt.Y))
+			ss := Style().SetStylePt(StyleVarItemSpacing, tc.pt)
+			assert.Equal(t, expected.styles, ss.styles)
+		})
+	}
+}
+
+func Test_StyleSetter_SetFontSize(t *testing.T) {
+	prevExtraFonts := extraFonts
+	defer func() {
+		extraFonts = prevExtraFonts
+	}()
+
+	font := &FontInfo{fontName: "test font", size: 12}
+	n := len(extraFonts)
+
+	for i := 0; i < 1000; i++ {
+		Style().SetFont(font).SetFontSize(20)
+	}
+
+	assert.Len(t, extraFonts, n+1, "the same font should be added once")
+
+	ss := Style().SetFont(font).SetFontSize(24)
+	assert.Len(t, extraFonts, n+2, "font of another size should be added")
+	assert.Equal(t, float32(24), ss.font.size)
+}
+
+func Test_GetStyleColor(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("style color")
+
+	col := color.RGBA{R: 10, G: 20, B: 30, A: 128}
+
+	PushStyleColor(StyleColorButton, col)
+	result := GetStyleColor(StyleColorButton)
+	PopStyleColor()
+
+	imgui.End()
+	imgui.Render()
+
+	assert.Equal(t, col, result, "unexpected color (alpha should be kept)")
+}
+
+func Test_GetStyleVar(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("style var")
+
+	tests := []struct {
+		id   StyleVarID
+		x, y float32
+	}{
+		{StyleVarWindowPadding, 1, 2},
+		{StyleVarFramePadding, 3, 4},
+		{StyleVarItemSpacing, 5, 6},
+		{StyleVarItemInnerSpacing, 7, 8},
+	}
+
+	for _, tc := range tests {
+		Style().SetStyle(tc.id, tc.x, tc.y).To(Custom(func() {
+			x, y := GetStyleVarVec2(tc.id)
+			assert.Equal(t, tc.x, x, "unexpected x of style var %d", tc.id)
+			assert.Equal(t, tc.y, y, "unexpected y of style var %d", tc.id)
+		})).Build()
+	}
+
+	assert.Panics(t, func() { GetStyleVarVec2(StyleVarFrameRounding) }, "float var read as vec2")
+	assert.Panics(t, func() { GetStyleVarVec2(StyleVarWindowMinSize) }, "vec2 var not exposed by imgui-go")
+
+	imgui.End()
+	imgui.Render()
+}
+
+func Test_PushStyleColorVec4(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("push color vec4")
+
+	style := imgui.CurrentStyle()
+	prev := style.GetColor(imgui.StyleColorButton)
+
+	PushStyleColorVec4(StyleColorButton, 0.1, 0.2, 0.3, 0.137)
+	assert.Equal(t, imgui.Vec4{X: 0.1, Y: 0.2, Z: 0.3, W: 0.137}, style.GetColor(imgui.StyleColorButton), "color wasn't pushed as it is")
+	PopStyleColor()
+
+	assert.Equal(t, prev, style.GetColor(imgui.StyleColorButton), "color wasn't popped")
+
+	imgui.End()
+	imgui.Render()
+}
+
+func Test_PushStyleVar(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("push style var")
+
+	style := imgui.CurrentStyle()
+	itemSpacing := style.ItemSpacing()
+
+	PushStyleVar(StyleVarItemSpacing, 3, 4)
+	assert.Equal(t, imgui.Vec2{X: 3, Y: 4}, style.ItemSpacing(), "vec2 var wasn't pushed")
+
+	// float var's value isn't exposed by imgui-go, so just push and pop it
+	assert.NotPanics(t, func() { PushStyleVar(StyleVarFrameRounding, 5) }, "float var wasn't pushed")
+	PopStyleVar()
+
+	PopStyleVar()
+	assert.Equal(t, itemSpacing, style.ItemSpacing(), "vec2 var wasn't popped")
+
+	tests := []struct {
+		name string
+		id   StyleVarID
+		v    []float32
+	}{
+		{"vec2 with one value", StyleVarItemSpacing, []float32{1}},
+		{"vec2 with three values", StyleVarItemSpacing, []float32{1, 2, 3}},
+		{"float with two values", StyleVarFrameRounding, []float32{1, 2}},
+		{"float without value", StyleVarFrameRounding, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Panics(t, func() { PushStyleVar(tc.id, tc.v...) }, "number of values wasn't checked")
+		})
+	}
+
+	imgui.End()
+	imgui.Render()
+}
@@ -2,10 +2,19 @@ package giu
 
 import (
 	"fmt"
+	"image"
+	"image/color"
 	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/AllenDang/imgui-go"
 	"github.com/sahilm/fuzzy"
+	"golang.org/x/image/colornames"
 )
 
 var _ Widget = &InputTextMultilineWidget{}
@@ -19,6 +28,23 @@ type InputTextMultilineWidget struct {
 	flags         InputTextFlags
 	cb            imgui.InputTextCallback
 	onChange      func()
+	onChangeEx    func(text string, cursorBytePos int)
+	findReplace   bool
+	noWrap        bool
+	autoScroll    bool
+	readOnly      bool
+}
+
+var _ Disposable = &autoScrollState{}
+
+type autoScrollState struct {
+	// length of the text in the previous frame
+	prevLen int
+}
+
+// Dispose implements Disposable interface.
+func (s *autoScrollState) Dispose() {
+	// noop
 }
 
 // InputTextMultiline creates InputTextMultilineWidget.
@@ -45,19 +71,197 @@ func (i *InputTextMultilineWidget) Labelf(format string, args ...interface{}) *I
 	return i.Label(fmt.Sprintf(format, args...))
 }
 
+// EnableFindReplace enables find bar (opened by Ctrl+F when the input is active)
+// with case sensitive search, replace and replace all.
+// Matches are highlighted only if the whole text fits in the input (is not scrolled).
+func (i *InputTextMultilineWidget) EnableFindReplace(enable bool) *InputTextMultilineWidget {
+	i.findReplace = enable
+	return i
+}
+
+// NoWrap makes the input show a horizontal scrollbar for long lines
+// (e.g. for a log pane; combine with InputTextFlagsReadOnly).
+// NOTE: imgui's multiline input never wraps the lines; without NoWrap
+// it scrolls horizontally only to follow the cursor (disabled by
+// InputTextFlagsNoHorizontalScroll) and shows no horizontal scrollbar.
+// With NoWrap, the input is placed in a child window and sized to fit
+// the whole text, so the child window's scrollbars are used instead.
+func (i *InputTextMultilineWidget) NoWrap(noWrap bool) *InputTextMultilineWidget {
+	i.noWrap = noWrap
+	return i
+}
+
+// AutoScrollToBottom scrolls the input to the bottom whenever the text
+// changes (e.g. for a log viewer; combine with InputTextFlagsReadOnly).
+// Between the changes, the input could be scrolled by user as usual.
+// Like with NoWrap, the input is placed in a scrolled child window
+// (imgui doesn't allow to scroll the input itself).
+func (i *InputTextMultilineWidget) AutoScrollToBottom(autoScroll bool) *InputTextMultilineWidget {
+	i.autoScroll = autoScroll
+	return i
+}
+
 // Build implements Widget interface.
 func (i *InputTextMultilineWidget) Build() {
-	if imgui.InputTextMultilineV(
+	flags, cb := i.flags, i.cb
+	if i.readOnly {
+		flags |= InputTextFlagsReadOnly
+	}
+	size := imgui.Vec2{X: i.width, Y: i.height}
+
+	var (
+		state      *findReplaceState
+		isReplaced bool
+	)
+
+	if i.findReplace {
+		stateID := i.label + "##findReplace"
+		if s := Context.GetState(stateID); s == nil {
+			state = &findReplaceState{}
+			Context.SetState(stateID, state)
+		} else {
+			var isOk bool
+			state, isOk = s.(*findReplaceState)
+			Assert(isOk, "InputTextMultilineWidget", "Build", "wrong state type recovered.")
+		}
+
+		isReplaced = state.buildBar(i.label, i.text)
+
+		if state.focusInput {
+			imgui.SetKeyboardFocusHere()
+			state.focusInput = false
+		}
+
+		flags |= InputTextFlagsCallbackAlways
+		cb = state.callback(i.cb, i.flags)
+	}
+
+	var cursorPos int
+
+	if i.onChangeEx != nil {
+		innerFlags, innerCb := flags, cb
+		flags |= InputTextFlagsCallbackAlways
+		cb = func(data imgui.InputTextCallbackData) int32 {
+			eventFlag := InputTextFlags(data.EventFlag())
+
+			// called after the buffer is updated
+			if eventFlag == InputTextFlagsCallbackAlways {
+				cursorPos = data.CursorPos()
+			}
+
+			if innerCb != nil && innerFlags&eventFlag != 0 {
+				return innerCb(data)
+			}
+
+			return 0
+		}
+	}
+
+	if i.noWrap || i.autoScroll {
+		size = i.beginScrollingChild()
+		defer imgui.EndChild()
+	}
+
+	isChanged := imgui.InputTextMultilineV(
 		tStr(i.label),
 		tStrPtr(i.text),
-		imgui.Vec2{
-			X: i.width,
-			Y: i.height,
-		},
-		int(i.flags), i.cb,
-	) && i.onChange != nil {
-		i.onChange()
+		size,
+		int(flags), cb,
+	)
+
+	// replaced text is reported like the typed one (with the cursor after the replacement)
+	if isReplaced && !isChanged {
+		isChanged = true
+		cursorPos = state.selection[1]
+
+		if cursorPos > len(*i.text) {
+			cursorPos = len(*i.text)
+		}
+	}
+
+	if isChanged {
+		if i.onChange != nil {
+			i.onChange()
+		}
+
+		if i.onChangeEx != nil {
+			i.onChangeEx(*i.text, cursorPos)
+		}
+	}
+
+	if state != nil {
+		state.handleInput(*i.text)
+	}
+
+	if i.autoScroll {
+		i.scrollToBottom()
+	}
+}
+
+// scrollToBottom scrolls the child window (see beginScrollingChild) to the bottom
+// if the text's length changed since the previous frame.
+func (i *InputTextMultilineWidget) scrollToBottom() {
+	stateID := i.label + "##autoScroll"
+
+	var state *autoScrollState
+	if s := Context.GetState(stateID); s == nil {
+		state = &autoScrollState{}
+		Context.SetState(stateID, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*autoScrollState)
+		Assert(isOk, "InputTextMultilineWidget", "Build", "wrong state type recovered.")
+	}
+
+	if len(*i.text) != state.prevLen {
+		// the cursor is below the input
+		imgui.SetScrollHereY(1)
+		state.prevLen = len(*i.text)
+	}
+}
+
+// beginScrollingChild begins a child window (with a horizontal scrollbar
+// if NoWrap is set). It returns size of the input fitting the whole text.
+func (i *InputTextMultilineWidget) beginScrollingChild() imgui.Vec2 {
+	padding := imgui.CurrentStyle().FramePadding()
+
+	// the same default size as InputTextMultiline uses
+	size := imgui.Vec2{X: i.width, Y: i.height}
+	if size.X == 0 {
+		size.X = imgui.CalcItemWidth()
+	}
+
+	if size.Y == 0 {
+		size.Y = imgui.TextLineHeight()*8 + 2*padding.Y
+	}
+
+	var flags WindowFlags
+	if i.noWrap {
+		flags = WindowFlagsHorizontalScrollbar
+	}
+
+	PushWindowPadding(0, 0)
+	imgui.BeginChildV(i.label+"##noWrap", size, false, int(flags))
+	PopStyle()
+
+	textWidth, textHeight := CalcTextSize(*i.text)
+	availableW, availableH := GetAvailableRegion()
+
+	// leave some space for the cursor at the end of the longest line
+	inputSize := imgui.Vec2{
+		X: textWidth + 2*padding.X + imgui.FontSize(),
+		Y: textHeight + 2*padding.Y,
+	}
+
+	if !i.noWrap || inputSize.X < availableW {
+		inputSize.X = availableW
+	}
+
+	if inputSize.Y < availableH {
+		inputSize.Y = availableH
 	}
+
+	return inputSize
 }
 
 // Flags sets InputTextFlags (see Flags.go).
@@ -66,6 +270,13 @@ func (i *InputTextMultilineWidget) Flags(flags InputTextFlags) *InputTextMultili
 	return i
 }
 
+// ReadOnly makes the input read-only (the text can still be selected and copied).
+// It is added to the flags set by Flags.
+func (i *InputTextMultilineWidget) ReadOnly(readOnly bool) *InputTextMultilineWidget {
+	i.readOnly = readOnly
+	return i
+}
+
 // Callback sets imgui.InputTextCallback.
 func (i *InputTextMultilineWidget) Callback(cb imgui.InputTextCallback) *InputTextMultilineWidget {
 	i.cb = cb
@@ -78,12 +289,127 @@ func (i *InputTextMultilineWidget) OnChange(onChange func()) *InputTextMultiline
 	return i
 }
 
+// OnChangeEx sets callback called (after OnChange) when the text was changed.
+// It receives the new text and the cursor position (byte offset into the text).
+func (i *InputTextMultilineWidget) OnChangeEx(onChange func(text string, cursorBytePos int)) *InputTextMultilineWidget {
+	i.onChangeEx = onChange
+	return i
+}
+
 // Size sets input field size.
 func (i *InputTextMultilineWidget) Size(width, height float32) *InputTextMultilineWidget {
 	i.width, i.height = width, height
 	return i
 }
 
+var _ Disposable = &growingInputState{}
+
+type growingInputState struct {
+	// set after submit to keep the input focused
+	shouldRefocus bool
+}
+
+// Dispose implements Disposable interface.
+func (s *growingInputState) Dispose() {
+	// noop
+}
+
+var _ Widget = &GrowingInputWidget{}
+
+// GrowingInputWidget is a text input (e.g. a chat composer), which looks like
+// a single-line input, but grows (up to MaxLines) when the text has more lines.
+// Enter submits the text (see OnSubmit) and Shift+Enter inserts a new line.
+// NOTE: like InputTextMultiline, it doesn't wrap long lines.
+type GrowingInputWidget struct {
+	label    string
+	value    *string
+	width    float32
+	maxLines int
+	onSubmit func()
+}
+
+// GrowingInput creates a new GrowingInputWidget.
+func GrowingInput(value *string) *GrowingInputWidget {
+	return &GrowingInputWidget{
+		label:    GenAutoID("##GrowingInput"),
+		value:    value,
+		width:    0,
+		maxLines: 5,
+		onSubmit: nil,
+	}
+}
+
+// Label sets input field label.
+func (g *GrowingInputWidget) Label(label string) *GrowingInputWidget {
+	g.label = tStr(label)
+	return g
+}
+
+// Size sets input's width.
+func (g *GrowingInputWidget) Size(width float32) *GrowingInputWidget {
+	g.width = width
+	return g
+}
+
+// MaxLines sets maximal number of lines the input grows to
+// (then a scrollbar is displayed).
+func (g *GrowingInputWidget) MaxLines(n int) *GrowingInputWidget {
+	g.maxLines = n
+	return g
+}
+
+// OnSubmit sets callback called when user presses Enter.
+func (g *GrowingInputWidget) OnSubmit(onSubmit func()) *GrowingInputWidget {
+	g.onSubmit = onSubmit
+	return g
+}
+
+// Build implements Widget interface.
+func (g *GrowingInputWidget) Build() {
+	var state *growingInputState
+	if s := Context.GetState(g.label); s == nil {
+		state = &growingInputState{}
+		Context.SetState(g.label, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*growingInputState)
+		Assert(isOk, "GrowingInputWidget", "Build", "unexpected state recovered")
+	}
+
+	lineHeight := imgui.TextLineHeight()
+	_, textHeight := CalcTextSize(*g.value)
+
+	lines := int(math.Round(float64(textHeight / lineHeight)))
+	if lines < 1 {
+		lines = 1
+	}
+
+	if g.maxLines > 0 && lines > g.maxLines {
+		lines = g.maxLines
+	}
+
+	height := float32(lines)*lineHeight + 2*imgui.CurrentStyle().FramePadding().Y
+
+	// without Shift, Enter deactivates the input (Ctrl+Enter inserts a new line then)
+	flags := InputTextFlagsEnterReturnsTrue
+	if !IsKeyDown(KeyLeftShift) && !IsKeyDown(KeyRightShift) {
+		flags |= InputTextFlagsCtrlEnterForNewLine
+	}
+
+	if state.shouldRefocus {
+		imgui.SetKeyboardFocusHere()
+		state.shouldRefocus = false
+	}
+
+	if imgui.InputTextMultilineV(g.label, tStrPtr(g.value), imgui.Vec2{X: g.width, Y: height}, int(flags), nil) {
+		state.shouldRefocus = true
+
+		if g.onSubmit != nil {
+			g.onSubmit()
+		}
+	}
+}
+
 var _ Widget = &BulletWidget{}
 
 // BulletWidget adds a small, white dot (bullet).
@@ -105,7 +431,8 @@ var _ Widget = &BulletTextWidget{}
 // BulletTextWidget does similar to BulletWidget, but allows
 // to add a text after a bullet. Very useful to create lists.
 type BulletTextWidget struct {
-	text string
+	text    string
+	wrapped bool
 }
 
 // BulletText creates bulletTextWidget.
@@ -120,9 +447,26 @@ func BulletTextf(format string, args ...interface{}) *BulletTextWidget {
 	return BulletText(fmt.Sprintf(format, args...))
 }
 
+// Wrapped makes the text wrap at the window's edge.
+// Wrapped lines are aligned with the text after the bullet.
+func (bt *BulletTextWidget) Wrapped(wrapped bool) *BulletTextWidget {
+	bt.wrapped = wrapped
+	return bt
+}
+
 // Build implements Widget interface.
 func (bt *BulletTextWidget) Build() {
-	imgui.BulletText(bt.text)
+	if !bt.wrapped {
+		imgui.BulletText(bt.text)
+		return
+	}
+
+	// Bullet moves the cursor after the bullet and its spacing,
+	// and all lines of a wrapped text start at the cursor's x.
+	imgui.Bullet()
+	PushTextWrapPos()
+	imgui.Text(bt.text)
+	PopTextWrapPos()
 }
 
 var _ Widget = &InputTextWidget{}
@@ -132,14 +476,169 @@ type InputTextWidget struct {
 	hint       string
 	value      *string
 	width      float32
-	candidates []string
-	flags      InputTextFlags
-	cb         imgui.InputTextCallback
-	onChange   func()
+	candidates []AutoCompleteItem
+	// max number of candidates shown (0 means no limit)
+	autoCompleteLimit   int
+	autoCompleteMatcher AutoCompleteMatcher
+	// complete on tab instead of enter
+	autoCompleteOnTab bool
+	// show the completion inline instead of the popup
+	ghostComplete  bool
+	flashColor     color.Color
+	flashDuration  time.Duration
+	scrollIntoView bool
+	regex          string
+	onMatch        func(groups []string)
+	flags          InputTextFlags
+	cb             imgui.InputTextCallback
+	onChange       func()
+	onEdit         func(text string, lastChar rune)
+	sensitive      bool
+	// value set by SetValue (nil if none)
+	newValue *string
+	// max number of characters (0 means no limit)
+	maxLength      int
+	allowedChars   string
+	deniedChars    string
+	password       bool
+	passwordReveal bool
+	clearButton    bool
+	readOnly       bool
+	commitOnEnter  bool
+
+	onChangeWithPrev func(oldValue, newValue string)
+}
+
+// AutoCompleteItem represents an InputTextWidget's autocomplete candidate.
+type AutoCompleteItem struct {
+	// Text is a candidate's text.
+	Text string
+	// Disabled items are shown dimmed, but can't be selected
+	// (e.g. category headers).
+	Disabled bool
+}
+
+// autoCompleteSource implements fuzzy.Source.
+type autoCompleteSource []AutoCompleteItem
+
+func (s autoCompleteSource) String(i int) string {
+	return s[i].Text
+}
+
+func (s autoCompleteSource) Len() int {
+	return len(s)
+}
+
+// AutoCompleteMatcher returns candidates matching input in the order
+// they should be shown (see InputTextWidget.AutoCompleteMatcher).
+type AutoCompleteMatcher func(input string, candidates []string) []string
+
+// PrefixAutoCompleteMatcher returns AutoCompleteMatcher matching
+// candidates starting with the input (e.g. for file paths or commands).
+// The candidates' order is kept.
+func PrefixAutoCompleteMatcher(caseSensitive bool) AutoCompleteMatcher {
+	return func(input string, candidates []string) (result []string) {
+		if !caseSensitive {
+			input = strings.ToLower(input)
+		}
+
+		for _, c := range candidates {
+			candidate := c
+			if !caseSensitive {
+				candidate = strings.ToLower(candidate)
+			}
+
+			if strings.HasPrefix(candidate, input) {
+				result = append(result, c)
+			}
+		}
+
+		return result
+	}
+}
+
+// findAutoCompleteMatches returns candidates matching value ordered by
+// the match's rank (fuzzy search is used if matcher is nil).
+// It returns at most limit candidates (0 means no limit).
+func findAutoCompleteMatches(value string, candidates []AutoCompleteItem, limit int, matcher AutoCompleteMatcher) []AutoCompleteItem {
+	var result []AutoCompleteItem
+
+	if matcher != nil {
+		texts := make([]string, len(candidates))
+		for idx, c := range candidates {
+			texts[idx] = c.Text
+		}
+
+		for _, text := range matcher(value, texts) {
+			item := AutoCompleteItem{Text: text}
+			// keep e.g. disabled state of the candidate
+			for _, c := range candidates {
+				if c.Text == text {
+					item = c
+					break
+				}
+			}
+
+			result = append(result, item)
+		}
+	} else {
+		for _, m := range fuzzy.FindFrom(value, autoCompleteSource(candidates)) {
+			result = append(result, candidates[m.Index])
+		}
+	}
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
 }
 
 type inputTextState struct {
-	autoCompleteCandidates fuzzy.Matches
+	autoCompleteCandidates []AutoCompleteItem
+	// index of candidate selected by pressing tab (-1 if none)
+	tabCandidate int
+	// index of candidate highlighted by arrow keys (-1 if none)
+	highlightedCandidate int
+	// set when value was changed by tab-completion
+	isTabCompleted bool
+	flash          flashAnimation
+	// compiled InputTextWidget.regex
+	regex *regexp.Regexp
+	// character typed in current frame (reported by OnEdit)
+	editChar rune
+	// set by (*InputTextWidget).Sensitive
+	sensitive bool
+	// rest of the best candidate shown by GhostComplete
+	ghostSuffix string
+	// set if the cursor is at the end of the text (for GhostComplete)
+	isCursorAtEnd bool
+	// set if the input was active in the previous frame
+	isActive bool
+	// value set by SetValue to be put into the active input's buffer
+	pendingValue *string
+	// set when the buffer was replaced by pendingValue
+	isValueSet bool
+	// number of characters and selected characters of the active input (for MaxLength)
+	runeCount     int
+	selectedRunes int
+	// set if the password is shown as a plain text (see PasswordReveal)
+	isRevealed bool
+	// value when the input was activated (see CommitOnEnter)
+	commitBase string
+}
+
+// sensitiveRedacted replaces sensitive values in debug output.
+const sensitiveRedacted = "******"
+
+// String implements fmt.Stringer. Values of sensitive inputs are redacted.
+func (s *inputTextState) String() string {
+	candidates := fmt.Sprint(s.autoCompleteCandidates)
+	if s.sensitive {
+		candidates = sensitiveRedacted
+	}
+
+	return fmt.Sprintf("inputTextState{candidates: %s, sensitive: %t}", candidates, s.sensitive)
 }
 
 func (s *inputTextState) Dispose() {
@@ -148,13 +647,14 @@ func (s *inputTextState) Dispose() {
 
 func InputText(value *string) *InputTextWidget {
 	return &InputTextWidget{
-		label:    GenAutoID("##InputText"),
-		hint:     "",
-		value:    value,
-		width:    0,
-		flags:    0,
-		cb:       nil,
-		onChange: nil,
+		label:             GenAutoID("##InputText"),
+		hint:              "",
+		value:             value,
+		width:             0,
+		autoCompleteLimit: 5,
+		flags:             0,
+		cb:                nil,
+		onChange:          nil,
 	}
 }
 
@@ -168,9 +668,86 @@ func (i *InputTextWidget) Labelf(format string, args ...interface{}) *InputTextW
 }
 
 // AutoComplete enables auto complete popup by using fuzzy search of current value against candidates
-// Press enter to confirm the first candidate.
+// Press enter to confirm the first candidate. Up and Down arrows highlight another candidate
+// (confirmed by enter) and Escape dismisses the popup without changing the value.
 func (i *InputTextWidget) AutoComplete(candidates []string) *InputTextWidget {
-	i.candidates = candidates
+	i.candidates = make([]AutoCompleteItem, len(candidates))
+	for idx, c := range candidates {
+		i.candidates[idx] = AutoCompleteItem{Text: c}
+	}
+
+	return i
+}
+
+// AutoCompleteItems does similar to AutoComplete, but allows to
+// mark some candidates as disabled (e.g. headers).
+// Disabled candidates are shown, but they can't be confirmed.
+func (i *InputTextWidget) AutoCompleteItems(items ...AutoCompleteItem) *InputTextWidget {
+	i.candidates = items
+	return i
+}
+
+// AutoCompleteLimit sets max number of candidates shown
+// by AutoComplete (5 by default, 0 means no limit).
+func (i *InputTextWidget) AutoCompleteLimit(n int) *InputTextWidget {
+	i.autoCompleteLimit = n
+	return i
+}
+
+// AutoCompleteMatcher replaces the default fuzzy search of AutoComplete
+// (and GhostComplete) candidates (see e.g. PrefixAutoCompleteMatcher).
+// The candidates are shown in the order returned by the matcher;
+// AutoCompleteLimit is applied to the matcher's result.
+func (i *InputTextWidget) AutoCompleteMatcher(matcher AutoCompleteMatcher) *InputTextWidget {
+	i.autoCompleteMatcher = matcher
+	return i
+}
+
+// AutoCompleteOnTab makes Tab (instead of Enter) accept the top candidate.
+// Pressing Tab repeatedly cycles through candidates (Shift+Tab cycles backward).
+// The focus is kept in the field while candidates are shown.
+func (i *InputTextWidget) AutoCompleteOnTab(onTab bool) *InputTextWidget {
+	i.autoCompleteOnTab = onTab
+	return i
+}
+
+// GhostComplete shows the rest of the best autocomplete candidate (see AutoComplete)
+// as a greyed text after the typed one instead of the popup.
+// Right arrow (at the end of the text) or Tab accepts the completion.
+// Only candidates starting with the typed text are suggested this way.
+func (i *InputTextWidget) GhostComplete(ghost bool) *InputTextWidget {
+	i.ghostComplete = ghost
+	return i
+}
+
+// Flash starts animating field's background towards col and back
+// over the duration. Call it in the frame in which the flash should start
+// (e.g. after validation error).
+func (i *InputTextWidget) Flash(col color.Color, duration time.Duration) *InputTextWidget {
+	i.flashColor, i.flashDuration = col, duration
+	return i
+}
+
+// ScrollIntoViewIf scrolls the window to the field if scroll is true
+// (e.g. to jump to invalid field on save). See also ScrollToItem.
+func (i *InputTextWidget) ScrollIntoViewIf(scroll bool) *InputTextWidget {
+	i.scrollIntoView = scroll
+	return i
+}
+
+// Regex enables validation of the value against the pattern.
+// If the value doesn't match, the field is marked as invalid
+// (red border and a tooltip on hover).
+// NOTE: invalid pattern causes panic.
+func (i *InputTextWidget) Regex(pattern string) *InputTextWidget {
+	i.regex = pattern
+	return i
+}
+
+// OnMatch sets callback called when the value was changed and it matches
+// the Regex pattern. groups are the submatches (groups[0] is the whole match).
+func (i *InputTextWidget) OnMatch(onMatch func(groups []string)) *InputTextWidget {
+	i.onMatch = onMatch
 	return i
 }
 
@@ -189,6 +766,13 @@ func (i *InputTextWidget) Flags(flags InputTextFlags) *InputTextWidget {
 	return i
 }
 
+// ReadOnly makes the input read-only (the text can still be selected and copied).
+// It is added to the flags set by Flags; ClearButton isn't shown.
+func (i *InputTextWidget) ReadOnly(readOnly bool) *InputTextWidget {
+	i.readOnly = readOnly
+	return i
+}
+
 func (i *InputTextWidget) Callback(cb imgui.InputTextCallback) *InputTextWidget {
 	i.cb = cb
 	return i
@@ -199,12 +783,115 @@ func (i *InputTextWidget) OnChange(onChange func()) *InputTextWidget {
 	return i
 }
 
+// Sensitive marks the input as containing a sensitive value (e.g. password),
+// so that its data is redacted in debug output of the state.
+func (i *InputTextWidget) Sensitive(sensitive bool) *InputTextWidget {
+	i.sensitive = sensitive
+	return i
+}
+
+// OnChangeWithPrev sets callback called when the value changes (e.g. to record undo).
+// It receives the value before and after the change.
+func (i *InputTextWidget) OnChangeWithPrev(onChange func(oldValue, newValue string)) *InputTextWidget {
+	i.onChangeWithPrev = onChange
+	return i
+}
+
+// OnEdit sets callback called when user types a character.
+// It receives the text after the edit and the typed character.
+// NOTE: it fires within the input text callback context (while the
+// InputText is being built), so it should not build any widgets.
+func (i *InputTextWidget) OnEdit(onEdit func(text string, lastChar rune)) *InputTextWidget {
+	i.onEdit = onEdit
+	return i
+}
+
+// Password masks the value (see also PasswordReveal).
+// Password inputs are also Sensitive.
+func (i *InputTextWidget) Password(password bool) *InputTextWidget {
+	i.password = password
+	return i
+}
+
+// PasswordReveal masks the value (see Password) and adds a toggle button
+// on the same line, which shows the plain text until it is clicked again.
+func (i *InputTextWidget) PasswordReveal() *InputTextWidget {
+	i.password = true
+	i.passwordReveal = true
+
+	return i
+}
+
+// MaxLength limits number of characters (runes) of the value.
+// Typed characters beyond the limit are rejected and pasted text is truncated.
+// It works together with the callback set by Callback.
+func (i *InputTextWidget) MaxLength(n int) *InputTextWidget {
+	i.maxLength = n
+	return i
+}
+
+// ClearButton adds a small "x" button after the input, which clears the value
+// (and calls OnChange). The button is shown only if the value isn't empty,
+// but its space is always reserved (the input is narrower by the button's width),
+// so that the layout doesn't change while typing.
+func (i *InputTextWidget) ClearButton() *InputTextWidget {
+	i.clearButton = true
+	return i
+}
+
+// CommitOnEnter makes OnChange called only when the value is committed:
+// when user presses Enter or the input loses focus (and the value
+// differs from the one before editing), e.g. for expensive handlers.
+// The value is still updated on each keystroke.
+// With AutoComplete, Enter confirming a candidate commits the candidate.
+// OnChangeWithPrev is called on commit as well; it receives the value before editing.
+func (i *InputTextWidget) CommitOnEnter() *InputTextWidget {
+	i.commitOnEnter = true
+	return i
+}
+
+// AllowedChars makes the input accept only the given characters
+// (typed or pasted), e.g. AllowedChars("0123456789abcdefABCDEF").
+// It works together with DeniedChars, MaxLength and the callback set by Callback.
+func (i *InputTextWidget) AllowedChars(chars string) *InputTextWidget {
+	i.allowedChars = chars
+	return i
+}
+
+// DeniedChars makes the input reject the given characters (typed or pasted).
+// It works together with AllowedChars, MaxLength and the callback set by Callback.
+func (i *InputTextWidget) DeniedChars(chars string) *InputTextWidget {
+	i.deniedChars = chars
+	return i
+}
+
+// isCharAllowed returns true if c is in allowed (or allowed is empty)
+// and it isn't in denied.
+func isCharAllowed(c rune, allowed, denied string) bool {
+	if allowed != "" && !strings.ContainsRune(allowed, c) {
+		return false
+	}
+
+	return !strings.ContainsRune(denied, c)
+}
+
+// SetValue sets the value from code (e.g. to clear the field or insert a snippet).
+// If the input isn't focused, it is the same as setting *value.
+// While the input is focused, imgui edits its own copy of the text and
+// would overwrite the value in the next frame, so the new value is also
+// put into imgui's buffer (in the input text callback) and the cursor is
+// moved to its end. OnChange isn't called for values set this way.
+func (i *InputTextWidget) SetValue(value string) *InputTextWidget {
+	i.newValue = &value
+	return i
+}
+
 // Build implements Widget interface.
 func (i *InputTextWidget) Build() {
 	// Get state
 	var state *inputTextState
 	if s := Context.GetState(i.label); s == nil {
-		state = &inputTextState{}
+		state = &inputTextState{tabCandidate: -1, highlightedCandidate: -1}
 		Context.SetState(i.label, state)
 	} else {
 		var isOk bool
@@ -212,210 +899,1947 @@ func (i *InputTextWidget) Build() {
 		Assert(isOk, "InputTextWidget", "Build", "wrong state type recovered.")
 	}
 
+	state.sensitive = i.sensitive || i.password
+
+	if i.newValue != nil {
+		*i.value = *i.newValue
+		if state.isActive {
+			state.pendingValue = i.newValue
+		}
+	}
+
+	// with CommitOnEnter, it is called by commit
+	if i.onChangeWithPrev != nil && !i.commitOnEnter {
+		prevValue := *i.value
+		defer func() {
+			if *i.value != prevValue {
+				i.onChangeWithPrev(prevValue, *i.value)
+			}
+		}()
+	}
+
 	if i.width != 0 {
 		PushItemWidth(i.width)
 		defer PopItemWidth()
 	}
 
-	isChanged := imgui.InputTextWithHint(i.label, i.hint, tStrPtr(i.value), int(i.flags), i.cb)
+	const clearButtonLabel = "x"
 
-	if isChanged && i.onChange != nil {
-		i.onChange()
-	}
+	innerSpacing := imgui.CurrentStyle().ItemInnerSpacing().X
 
-	if isChanged {
-		// Enable auto complete
-		if len(i.candidates) > 0 {
-			matches := fuzzy.Find(*i.value, i.candidates)
-			if matches.Len() > 0 {
-				size := int(math.Min(5, float64(matches.Len())))
-				matches = matches[:size]
+	var clearButtonW float32
 
-				state.autoCompleteCandidates = matches
-			}
-		}
+	hasClearButton := i.clearButton && !i.readOnly
+	if hasClearButton {
+		clearButtonW = calcTextWidth(clearButtonLabel, -1) + 2*imgui.CurrentStyle().FramePadding().X
+
+		PushItemWidth(imgui.CalcItemWidth() - clearButtonW - innerSpacing)
+		defer PopItemWidth()
+	}
+
+	if i.flashColor != nil {
+		state.flash.run(i.flashColor, i.flashDuration)
+	}
+
+	if factor := state.flash.factor(); factor > 0 {
+		frameBg := imgui.CurrentStyle().GetColor(imgui.StyleColorFrameBg)
+		imgui.PushStyleColor(imgui.StyleColorFrameBg, lerpVec4(frameBg, ToVec4Color(state.flash.color), factor))
+		defer imgui.PopStyleColor()
+	}
+
+	flags, cb := i.flags, i.cb
+
+	if i.password && !state.isRevealed {
+		flags |= InputTextFlagsPassword
+	}
+
+	if i.readOnly {
+		flags |= InputTextFlagsReadOnly
+	}
+
+	if i.commitOnEnter {
+		flags |= InputTextFlagsEnterReturnsTrue
+	}
+
+	isGhostCompletion := i.ghostComplete && state.ghostSuffix != ""
+	if isGhostCompletion {
+		flags |= InputTextFlagsCallbackCompletion | InputTextFlagsCallbackAlways
+	}
+
+	isTabCompletion := !isGhostCompletion && i.autoCompleteOnTab && len(state.autoCompleteCandidates) > 0
+	if isTabCompletion {
+		flags |= InputTextFlagsCallbackCompletion
+	}
+
+	if i.onEdit != nil {
+		flags |= InputTextFlagsCallbackCharFilter | InputTextFlagsCallbackAlways
+	}
+
+	if state.pendingValue != nil {
+		flags |= InputTextFlagsCallbackAlways
+	}
+
+	if i.maxLength > 0 {
+		flags |= InputTextFlagsCallbackCharFilter | InputTextFlagsCallbackAlways
+	}
+
+	isCharFiltered := i.allowedChars != "" || i.deniedChars != ""
+	if isCharFiltered {
+		flags |= InputTextFlagsCallbackCharFilter
+	}
+
+	if flags != i.flags {
+		cb = func(data imgui.InputTextCallbackData) int32 {
+			eventFlag := InputTextFlags(data.EventFlag())
+
+			if eventFlag == InputTextFlagsCallbackAlways && state.pendingValue != nil {
+				data.DeleteBytes(0, len(data.Buffer()))
+				data.InsertBytes(0, []byte(*state.pendingValue))
+				state.pendingValue = nil
+				state.isValueSet = true
+			}
+
+			if isTabCompletion && eventFlag == InputTextFlagsCallbackCompletion {
+				i.completeOnTab(state, data)
+				return 0
+			}
+
+			if isGhostCompletion {
+				switch eventFlag {
+				case InputTextFlagsCallbackCompletion:
+					acceptGhostCompletion(state, data)
+					return 0
+				case InputTextFlagsCallbackAlways:
+					state.isCursorAtEnd = data.CursorPos() == len(data.Buffer())
+					if state.isCursorAtEnd && IsKeyPressed(KeyRight) {
+						acceptGhostCompletion(state, data)
+					}
+				}
+			}
+
+			// imgui filters pasted text by the char filter as well
+			if isCharFiltered && eventFlag == InputTextFlagsCallbackCharFilter &&
+				!isCharAllowed(data.EventChar(), i.allowedChars, i.deniedChars) {
+				return 1
+			}
+
+			if i.maxLength > 0 {
+				switch eventFlag {
+				case InputTextFlagsCallbackCharFilter:
+					// typing over a selection replaces it
+					if state.runeCount-state.selectedRunes >= i.maxLength {
+						return 1
+					}
+				case InputTextFlagsCallbackAlways:
+					clampRunesAtCursor(data, i.maxLength)
+
+					buf := data.Buffer()
+					selStart, selEnd := data.SelectionStart(), data.SelectionEnd()
+					if selStart > selEnd {
+						selStart, selEnd = selEnd, selStart
+					}
+
+					state.runeCount = utf8.RuneCount(buf)
+					state.selectedRunes = utf8.RuneCount(buf[selStart:selEnd])
+				}
+			}
+
+			var result int32
+			if i.cb != nil && i.flags&eventFlag != 0 {
+				result = i.cb(data)
+			}
+
+			// more characters (e.g. pasted text) are filtered before
+			// the next CallbackAlways, so count the accepted ones
+			if i.maxLength > 0 && eventFlag == InputTextFlagsCallbackCharFilter && result == 0 {
+				state.runeCount = state.runeCount - state.selectedRunes + 1
+				state.selectedRunes = 0
+			}
+
+			if i.onEdit != nil {
+				switch eventFlag {
+				case InputTextFlagsCallbackCharFilter:
+					// the char will be inserted only if callback returns 0
+					if result == 0 {
+						state.editChar = data.EventChar()
+					}
+				case InputTextFlagsCallbackAlways:
+					// called after chars are inserted into the buffer
+					if state.editChar != 0 {
+						lastChar := state.editChar
+						state.editChar = 0
+						i.onEdit(string(data.Buffer()), lastChar)
+					}
+				}
+			}
+
+			return result
+		}
+	}
+
+	isValid := true
+	if i.regex != "" {
+		if state.regex == nil || state.regex.String() != i.regex {
+			r, err := regexp.Compile(i.regex)
+			Assert(err == nil, "InputTextWidget", "Build", "invalid regex pattern %q: %v", i.regex, err)
+			state.regex = r
+		}
+
+		isValid = state.regex.MatchString(*i.value)
+	}
+
+	if !isValid {
+		imgui.PushStyleColor(imgui.StyleColorBorder, ToVec4Color(colornames.Red))
+		imgui.PushStyleVarFloat(imgui.StyleVarFrameBorderSize, 1)
+	}
+
+	prevValue := *i.value
+	wasActive := state.isActive
+	// with the clear button, the label is shown after the button
+	inputLabel := i.label
+	if hasClearButton {
+		imgui.PushID(i.label)
+		inputLabel = "##input"
+	}
+
+	isChanged := imgui.InputTextWithHint(inputLabel, i.hint, tStrPtr(i.value), int(flags), cb)
+
+	if hasClearButton {
+		imgui.PopID()
+	}
+	state.isActive = imgui.IsItemActive()
+
+	// with InputTextFlagsEnterReturnsTrue, imgui reports only Enter
+	isCommitted := false
+	if i.commitOnEnter {
+		isCommitted = isChanged || (wasActive && !state.isActive)
+		isChanged = *i.value != prevValue
+
+		if !wasActive && state.isActive {
+			state.commitBase = prevValue
+		}
+	}
+
+	// Escape dismisses the autocomplete list;
+	// imgui would also revert the value to the one before editing.
+	// Keys are handled only if the input was active (Escape and Enter deactivate it).
+	if wasActive && len(state.autoCompleteCandidates) > 0 && IsKeyPressed(KeyEscape) {
+		*i.value = prevValue
+		isChanged = false
+		state.autoCompleteCandidates = nil
+	}
+
+	if i.maxLength > 0 {
+		*i.value = truncateRunes(*i.value, i.maxLength)
+	}
+
+	if state.isValueSet {
+		state.isValueSet = false
+		isChanged = false
+	}
+
+	if !isValid {
+		imgui.PopStyleVar()
+		imgui.PopStyleColor()
+
+		if imgui.IsItemHovered() {
+			imgui.SetTooltip(fmt.Sprintf("value doesn't match pattern %s", i.regex))
+		}
+	}
+
+	if isGhostCompletion && imgui.IsItemActive() && state.isCursorAtEnd {
+		drawGhostSuffix(*i.value, state.ghostSuffix)
+	}
+
+	if i.scrollIntoView {
+		ScrollToItem()
+	}
+
+	if isChanged && i.onChange != nil && !i.commitOnEnter {
+		i.onChange()
+	}
+
+	if isChanged && state.regex != nil && i.onMatch != nil {
+		if groups := state.regex.FindStringSubmatch(*i.value); groups != nil {
+			i.onMatch(groups)
+		}
+	}
+
+	// value changed by tab-completion - keep current candidates to allow cycling
+	if isChanged && state.isTabCompleted {
+		state.isTabCompleted = false
+		isChanged = false
+	} else if isChanged {
+		state.tabCandidate = -1
+	}
+
+	if isChanged && i.ghostComplete {
+		state.ghostSuffix = ""
+
+		if len(i.candidates) > 0 {
+			ordered := findAutoCompleteMatches(*i.value, i.candidates, 0, i.autoCompleteMatcher)
+			state.ghostSuffix = ghostCompletionSuffix(*i.value, ordered)
+		}
+	} else if isChanged {
+		// Enable auto complete
+		if len(i.candidates) > 0 {
+			state.highlightedCandidate = -1
+
+			if matches := findAutoCompleteMatches(*i.value, i.candidates, i.autoCompleteLimit, i.autoCompleteMatcher); len(matches) > 0 {
+				state.autoCompleteCandidates = matches
+			}
+		}
 	}
 
 	// Draw autocomplete list
 	if len(state.autoCompleteCandidates) > 0 {
+		// arrow keys move the highlight (tab-completion cycles the candidates itself)
+		if wasActive && !i.autoCompleteOnTab {
+			switch {
+			case IsKeyPressed(KeyDown):
+				state.highlightedCandidate = nextSelectableCandidate(state.autoCompleteCandidates, state.highlightedCandidate, false)
+			case IsKeyPressed(KeyUp):
+				state.highlightedCandidate = nextSelectableCandidate(state.autoCompleteCandidates, state.highlightedCandidate, true)
+			}
+		}
+
 		labels := make(Layout, len(state.autoCompleteCandidates))
-		for i, m := range state.autoCompleteCandidates {
-			labels[i] = Label(m.Str)
+		for idx, c := range state.autoCompleteCandidates {
+			if idx == state.highlightedCandidate {
+				labels[idx] = Selectable(c.Text).Selected(true)
+				continue
+			}
+
+			labels[idx] = Style().SetDisabled(c.Disabled).To(Label(c.Text))
+		}
+
+		SetNextWindowPos(i.calcAutoCompletePos(state.autoCompleteCandidates))
+		imgui.BeginTooltip()
+		labels.Build()
+		imgui.EndTooltip()
+
+		// Press enter will replace value string with the highlighted
+		// (or first selectable) match candidate
+		if wasActive && IsKeyPressed(KeyEnter) {
+			idx := state.highlightedCandidate
+			if idx < 0 {
+				idx = firstSelectableCandidate(state.autoCompleteCandidates)
+			}
+
+			if i.autoCompleteOnTab {
+				// value has already been completed by tab
+				state.autoCompleteCandidates = nil
+			} else if idx >= 0 {
+				*i.value = state.autoCompleteCandidates[idx].Text
+				// imgui would restore its buffer in the next frame
+				if state.isActive {
+					chosen := *i.value
+					state.pendingValue = &chosen
+				}
+
+				state.autoCompleteCandidates = nil
+			}
+
+			state.highlightedCandidate = -1
+		}
+	}
+
+	// after the autocomplete, which could replace the value on Enter
+	if isCommitted && *i.value != state.commitBase {
+		i.commit(state)
+	}
+
+	// built at the end; the code above uses the input's rect
+	if hasClearButton {
+		i.buildClearButton(state, clearButtonLabel, clearButtonW)
+	}
+
+	if i.passwordReveal {
+		buttonLabel := "Show"
+		if state.isRevealed {
+			buttonLabel = "Hide"
+		}
+
+		imgui.SameLine()
+
+		if imgui.Button(buttonLabel + "##reveal" + i.label) {
+			state.isRevealed = !state.isRevealed
+		}
+	}
+}
+
+// buildClearButton builds the clear button (see ClearButton) right after
+// the input (its space is kept while the value is empty) and the input's label.
+func (i *InputTextWidget) buildClearButton(state *inputTextState, buttonLabel string, buttonW float32) {
+	innerSpacing := imgui.CurrentStyle().ItemInnerSpacing().X
+
+	imgui.SameLineV(0, innerSpacing)
+
+	if *i.value == "" {
+		imgui.Dummy(imgui.Vec2{X: buttonW, Y: frameHeight()})
+	} else if imgui.Button(buttonLabel + "##clear" + i.label) {
+		*i.value = ""
+		// imgui would restore its buffer in the next frame
+		if state.isActive {
+			cleared := ""
+			state.pendingValue = &cleared
+		}
+
+		if i.commitOnEnter {
+			i.commit(state)
+		} else if i.onChange != nil {
+			i.onChange()
+		}
+	}
+
+	if label := visibleLabel(i.label); label != "" {
+		imgui.SameLineV(0, innerSpacing)
+		imgui.Text(label)
+	}
+}
+
+// commit calls OnChange and OnChangeWithPrev (with the value before editing)
+// when the value is committed (see CommitOnEnter).
+func (i *InputTextWidget) commit(state *inputTextState) {
+	oldValue := state.commitBase
+	state.commitBase = *i.value
+
+	if i.onChange != nil {
+		i.onChange()
+	}
+
+	if i.onChangeWithPrev != nil {
+		i.onChangeWithPrev(oldValue, *i.value)
+	}
+}
+
+// completeOnTab replaces input text's buffer with next (or previous if
+// shift is down) autocomplete candidate.
+func (i *InputTextWidget) completeOnTab(state *inputTextState, data imgui.InputTextCallbackData) {
+	backward := IsKeyDown(KeyLeftShift) || IsKeyDown(KeyRightShift)
+
+	idx := nextSelectableCandidate(state.autoCompleteCandidates, state.tabCandidate, backward)
+	if idx < 0 {
+		return
+	}
+
+	state.tabCandidate = idx
+	state.isTabCompleted = true
+
+	data.DeleteBytes(0, len(data.Buffer()))
+	data.InsertBytes(0, []byte(state.autoCompleteCandidates[idx].Text))
+}
+
+// runeLimitOffset returns byte offset of the n-th rune in text
+// (or len(text) if text has n runes or less).
+func runeLimitOffset(text []byte, n int) int {
+	offset := 0
+	for count := 0; offset < len(text); count++ {
+		if count == n {
+			return offset
+		}
+
+		_, size := utf8.DecodeRune(text[offset:])
+		offset += size
+	}
+
+	return len(text)
+}
+
+// clampRunesAtCursor removes characters beyond the limit of n runes
+// which were inserted just before the cursor (then from the end of the buffer).
+func clampRunesAtCursor(data imgui.InputTextCallbackData, n int) {
+	buf := data.Buffer()
+
+	excess := utf8.RuneCount(buf) - n
+	if excess <= 0 {
+		return
+	}
+
+	cursor := data.CursorPos()
+	start := cursor
+
+	for ; excess > 0 && start > 0; excess-- {
+		_, size := utf8.DecodeLastRune(buf[:start])
+		start -= size
+	}
+
+	data.DeleteBytes(start, cursor-start)
+
+	if excess > 0 {
+		buf = data.Buffer()
+		offset := runeLimitOffset(buf, n)
+		data.DeleteBytes(offset, len(buf)-offset)
+	}
+}
+
+// truncateRunes returns first n runes of s.
+func truncateRunes(s string, n int) string {
+	return s[:runeLimitOffset([]byte(s), n)]
+}
+
+// ghostCompletionSuffix returns the rest of the first selectable candidate
+// which starts with value (ignoring case), or "" if there is no such candidate.
+func ghostCompletionSuffix(value string, candidates []AutoCompleteItem) string {
+	if value == "" {
+		return ""
+	}
+
+	for _, c := range candidates {
+		if c.Disabled || len(c.Text) <= len(value) {
+			continue
+		}
+
+		if strings.EqualFold(c.Text[:len(value)], value) {
+			return c.Text[len(value):]
+		}
+	}
+
+	return ""
+}
+
+// acceptGhostCompletion appends ghost suffix to the input text's buffer.
+func acceptGhostCompletion(state *inputTextState, data imgui.InputTextCallbackData) {
+	data.InsertBytes(len(data.Buffer()), []byte(state.ghostSuffix))
+	state.ghostSuffix = ""
+}
+
+// drawGhostSuffix draws the suffix after the text of the last input text.
+// NOTE: it isn't drawn if the text doesn't fit in the field (the field is scrolled).
+func drawGhostSuffix(text, suffix string) {
+	itemMin, itemMax := imgui.GetItemRectMin(), imgui.GetItemRectMax()
+	paddingX, paddingY := GetFramePadding()
+	textWidth, _ := CalcTextSize(text)
+	suffixWidth, _ := CalcTextSize(suffix)
+
+	if itemMin.X+paddingX+textWidth+suffixWidth > itemMax.X-paddingX {
+		return
+	}
+
+	GetCanvas().AddText(
+		image.Pt(int(itemMin.X+paddingX+textWidth), int(itemMin.Y+paddingY)),
+		Vec4ToRGBA(imgui.CurrentStyle().GetColor(imgui.StyleColorTextDisabled)),
+		suffix,
+	)
+}
+
+// calcAutoCompletePos returns position of the autocomplete popup.
+// By default the popup is placed below the input field, but if there is
+// not enough space at the bottom of the display, it is flipped above the field.
+// It is also clamped horizontally so that it doesn't run off the right edge.
+// If there is no platform, the popup is always placed below the field.
+func (i *InputTextWidget) calcAutoCompletePos(candidates []AutoCompleteItem) (x, y float32) {
+	itemMin, itemMax := imgui.GetItemRectMin(), imgui.GetItemRectMax()
+	paddingX, paddingY := GetWindowPadding()
+
+	var popupW float32
+	for _, c := range candidates {
+		if w, _ := CalcTextSize(c.Text); w > popupW {
+			popupW = w
+		}
+	}
+
+	popupW += 2 * paddingX
+	popupH := float32(len(candidates))*imgui.TextLineHeightWithSpacing() + 2*paddingY
+
+	x, y = itemMin.X, itemMax.Y
+
+	// without a platform (e.g. headless) the display size is unknown
+	if Context.platform == nil {
+		return x, y
+	}
+
+	displaySize := Context.platform.DisplaySize()
+	displayW, displayH := displaySize[0], displaySize[1]
+
+	if y+popupH > displayH && itemMin.Y-popupH >= 0 {
+		y = itemMin.Y - popupH
+	}
+
+	if x+popupW > displayW {
+		x = displayW - popupW
+	}
+
+	if x < 0 {
+		x = 0
+	}
+
+	return x, y
+}
+
+// firstSelectableCandidate returns index of the first candidate which isn't disabled
+// or -1 if there is no such candidate.
+func firstSelectableCandidate(candidates []AutoCompleteItem) int {
+	for idx, c := range candidates {
+		if !c.Disabled {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// nextSelectableCandidate returns index of the next (or previous if backward is true)
+// candidate after current, which isn't disabled. The search wraps around.
+// If current is -1, the search starts at the beginning (or end) of the list.
+// It returns -1 if there is no selectable candidate.
+func nextSelectableCandidate(candidates []AutoCompleteItem, current int, backward bool) int {
+	n := len(candidates)
+	if n == 0 {
+		return -1
+	}
+
+	step := 1
+	if backward {
+		step = -1
+		if current < 0 {
+			current = n
+		}
+	}
+
+	for k := 1; k <= n; k++ {
+		idx := ((current+step*k)%n + n) % n
+		if !candidates[idx].Disabled {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+var _ Disposable = &labelDragState{}
+
+type labelDragState struct {
+	// part of the drag delta not applied to an integer value yet
+	remainder float32
+}
+
+// Dispose implements Disposable interface.
+func (s *labelDragState) Dispose() {
+	// noop
+}
+
+// buildWithLabelDrag builds a numeric input (by buildInput) and places a drag zone over its label.
+// It returns horizontal mouse movement multiplied by speed while user drags the label.
+// Input's label isn't a part of its frame, so clicking the frame still activates the text input.
+// Both are built in a group, so that the group (reporting the input's hovered
+// and active state, e.g. for IsItemHovered or a Tooltip) is the last item.
+func buildWithLabelDrag(label string, speed float32, buildInput func()) float32 {
+	imgui.BeginGroup()
+	defer imgui.EndGroup()
+
+	start := imgui.CursorScreenPos()
+	labelX := start.X + imgui.CalcItemWidth() + imgui.CurrentStyle().ItemInnerSpacing().X
+
+	buildInput()
+
+	labelSize := imgui.CalcTextSize(label, true, 0)
+	if labelSize.X == 0 {
+		return 0
+	}
+
+	imgui.SetCursorScreenPos(imgui.Vec2{X: labelX, Y: start.Y})
+	imgui.InvisibleButton(label+"##dragOnLabel", imgui.Vec2{X: labelSize.X, Y: frameHeight()})
+
+	isHovered, isActive := imgui.IsItemHovered(), imgui.IsItemActive()
+	if isHovered || isActive {
+		imgui.SetMouseCursor(imgui.MouseCursorResizeEW)
+	}
+
+	if !isActive {
+		return 0
+	}
+
+	return imgui.CurrentIO().GetMouseDelta().X * speed
+}
+
+var _ Widget = &InputIntWidget{}
+
+type InputIntWidget struct {
+	label     string
+	value     *int32
+	width     float32
+	flags     InputTextFlags
+	readOnly  bool
+	step      int
+	stepFast  int
+	dragSpeed float32
+	min, max  *int32
+	onChange  func()
+
+	onChangeWithPrev func(oldValue, newValue int32)
+}
+
+func InputInt(value *int32) *InputIntWidget {
+	return &InputIntWidget{
+		label:    GenAutoID("##InputInt"),
+		value:    value,
+		width:    0,
+		flags:    0,
+		stepFast: 100,
+		onChange: nil,
+	}
+}
+
+func (i *InputIntWidget) Label(label string) *InputIntWidget {
+	i.label = tStr(label)
+	return i
+}
+
+func (i *InputIntWidget) Labelf(format string, args ...interface{}) *InputIntWidget {
+	return i.Label(fmt.Sprintf(format, args...))
+}
+
+func (i *InputIntWidget) Size(width float32) *InputIntWidget {
+	i.width = width
+	return i
+}
+
+func (i *InputIntWidget) Flags(flags InputTextFlags) *InputIntWidget {
+	i.flags = flags
+	return i
+}
+
+// ReadOnly makes the input read-only; step buttons and DragOnLabel are disabled.
+// It is added to the flags set by Flags.
+func (i *InputIntWidget) ReadOnly(readOnly bool) *InputIntWidget {
+	i.readOnly = readOnly
+	return i
+}
+
+func (i *InputIntWidget) OnChange(onChange func()) *InputIntWidget {
+	i.onChange = onChange
+	return i
+}
+
+// OnChangeWithPrev sets callback called when the value changes (e.g. to record undo).
+// It receives the value before and after the change.
+func (i *InputIntWidget) OnChangeWithPrev(onChange func(oldValue, newValue int32)) *InputIntWidget {
+	i.onChangeWithPrev = onChange
+	return i
+}
+
+// Min sets the minimal value; edited values are clamped to it.
+func (i *InputIntWidget) Min(value int32) *InputIntWidget {
+	i.min = &value
+	return i
+}
+
+// Max sets the maximal value; edited values are clamped to it.
+func (i *InputIntWidget) Max(value int32) *InputIntWidget {
+	i.max = &value
+	return i
+}
+
+// Step sets step of the +/- buttons (step) and of the buttons clicked
+// with Ctrl held (stepFast). The buttons are hidden if step is 0 (default).
+func (i *InputIntWidget) Step(step, stepFast int) *InputIntWidget {
+	i.step, i.stepFast = step, stepFast
+	return i
+}
+
+// DragOnLabel makes the label a drag zone: dragging it horizontally changes
+// the value by speed per pixel (OnChange is called during the drag).
+// Clicking the field still allows to type the value.
+func (i *InputIntWidget) DragOnLabel(speed float32) *InputIntWidget {
+	i.dragSpeed = speed
+	return i
+}
+
+// Build implements Widget interface.
+func (i *InputIntWidget) Build() {
+	if i.width != 0 {
+		PushItemWidth(i.width)
+		defer PopItemWidth()
+	}
+
+	if i.onChangeWithPrev != nil {
+		prevValue := *i.value
+		defer func() {
+			if *i.value != prevValue {
+				i.onChangeWithPrev(prevValue, *i.value)
+			}
+		}()
+	}
+
+	flags := i.flags
+	if i.readOnly {
+		flags |= InputTextFlagsReadOnly
+	}
+
+	buildInput := func() {
+		if i.step == 0 {
+			if imgui.InputIntV(i.label, i.value, 0, 0, int(flags)) {
+				i.applyChange(*i.value)
+			}
+
+			return
+		}
+
+		i.buildWithStep(flags)
+	}
+
+	if i.dragSpeed != 0 && !i.readOnly {
+		i.buildWithDrag(buildInput)
+		return
+	}
+
+	buildInput()
+}
+
+// buildWithStep builds the input with -/+ buttons (see Step) the way imgui's InputInt does.
+// imgui-go's InputIntV ignores its step arguments, so the buttons are built here.
+func (i *InputIntWidget) buildWithStep(flags InputTextFlags) {
+	buttonSize := frameHeight()
+	innerSpacing := imgui.CurrentStyle().ItemInnerSpacing().X
+
+	imgui.BeginGroup()
+	defer imgui.EndGroup()
+
+	imgui.PushID(i.label)
+	defer imgui.PopID()
+
+	inputWidth := imgui.CalcItemWidth() - (buttonSize+innerSpacing)*2
+	if inputWidth < 1 {
+		inputWidth = 1
+	}
+
+	imgui.PushItemWidth(inputWidth)
+	isChanged := imgui.InputIntV("##value", i.value, 0, 0, int(flags))
+	imgui.PopItemWidth()
+
+	if isChanged {
+		i.applyChange(*i.value)
+	}
+
+	step := int32(i.step)
+	isCtrlDown := IsKeyDown(KeyLeftControl) || IsKeyDown(KeyRightControl)
+	if isCtrlDown && i.stepFast != 0 {
+		step = int32(i.stepFast)
+	}
+
+	imgui.BeginDisabled(i.readOnly)
+
+	imgui.SameLineV(0, innerSpacing)
+
+	if repeatButton("-", buttonSize) {
+		i.applyChange(*i.value - step)
+	}
+
+	imgui.SameLineV(0, innerSpacing)
+
+	if repeatButton("+", buttonSize) {
+		i.applyChange(*i.value + step)
+	}
+
+	imgui.EndDisabled()
+
+	if label := visibleLabel(i.label); label != "" {
+		imgui.SameLineV(0, innerSpacing)
+		imgui.Text(label)
+	}
+}
+
+// applyChange sets the value clamped to Min/Max and calls OnChange.
+func (i *InputIntWidget) applyChange(value int32) {
+	*i.value = clampInt32(value, i.min, i.max)
+
+	if i.onChange != nil {
+		i.onChange()
+	}
+}
+
+// repeatButton builds a square button which reports a press on click
+// and repeatedly while it is held (like imgui's step buttons).
+func repeatButton(id string, size float32) bool {
+	imgui.ButtonV(id, imgui.Vec2{X: size, Y: size})
+
+	return imgui.IsItemActive() && imgui.IsMouseClickedV(int(MouseButtonLeft), true)
+}
+
+// visibleLabel returns part of the label shown by imgui (without the "##" suffix).
+func visibleLabel(label string) string {
+	if idx := strings.Index(label, "##"); idx >= 0 {
+		return label[:idx]
+	}
+
+	return label
+}
+
+// buildWithDrag builds the input and changes the value while user drags the label (see DragOnLabel).
+// Fractional parts of the drag are accumulated in the state.
+func (i *InputIntWidget) buildWithDrag(buildInput func()) {
+	stateID := i.label + "##dragOnLabel"
+
+	var state *labelDragState
+	if s := Context.GetState(stateID); s == nil {
+		state = &labelDragState{}
+		Context.SetState(stateID, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*labelDragState)
+		Assert(isOk, "InputIntWidget", "buildWithDrag", "got unexpected type of widget's state")
+	}
+
+	state.remainder += buildWithLabelDrag(i.label, i.dragSpeed, buildInput)
+
+	step := int32(state.remainder)
+	if step == 0 {
+		return
+	}
+
+	state.remainder -= float32(step)
+	i.applyChange(*i.value + step)
+}
+
+// clampInt32 clamps value to [min, max]; nil bound isn't checked.
+func clampInt32(value int32, min, max *int32) int32 {
+	if min != nil && value < *min {
+		value = *min
+	}
+
+	if max != nil && value > *max {
+		value = *max
+	}
+
+	return value
+}
+
+// clampFloat32 clamps value to [min, max]; nil bound isn't checked.
+func clampFloat32(value float32, min, max *float32) float32 {
+	if min != nil && value < *min {
+		value = *min
+	}
+
+	if max != nil && value > *max {
+		value = *max
+	}
+
+	return value
+}
+
+var _ Widget = &InputFloatWidget{}
+
+type InputFloatWidget struct {
+	label     string
+	value     *float32
+	width     float32
+	flags     InputTextFlags
+	readOnly  bool
+	format    string
+	step      float32
+	stepFast  float32
+	nudgeStep float32
+	nudgeFast float32
+	dragSpeed float32
+	min, max  *float32
+	onChange  func()
+
+	onChangeWithPrev func(oldValue, newValue float32)
+}
+
+func InputFloat(value *float32) *InputFloatWidget {
+	return &InputFloatWidget{
+		label:    GenAutoID("##InputFloatWidget"),
+		width:    0,
+		value:    value,
+		format:   "%.3f",
+		flags:    0,
+		onChange: nil,
+	}
+}
+
+func (i *InputFloatWidget) Label(label string) *InputFloatWidget {
+	i.label = tStr(label)
+	return i
+}
+
+func (i *InputFloatWidget) Labelf(format string, args ...interface{}) *InputFloatWidget {
+	return i.Label(fmt.Sprintf(format, args...))
+}
+
+func (i *InputFloatWidget) Size(width float32) *InputFloatWidget {
+	i.width = width
+	return i
+}
+
+func (i *InputFloatWidget) Flags(flags InputTextFlags) *InputFloatWidget {
+	i.flags = flags
+	return i
+}
+
+// ReadOnly makes the input read-only; step buttons, ArrowNudge and DragOnLabel are disabled.
+// It is added to the flags set by Flags.
+func (i *InputFloatWidget) ReadOnly(readOnly bool) *InputFloatWidget {
+	i.readOnly = readOnly
+	return i
+}
+
+func (i *InputFloatWidget) Format(format string) *InputFloatWidget {
+	i.format = format
+	return i
+}
+
+func (i *InputFloatWidget) OnChange(onChange func()) *InputFloatWidget {
+	i.onChange = onChange
+	return i
+}
+
+// OnChangeWithPrev sets callback called when the value changes (e.g. to record undo).
+// It receives the value before and after the change.
+func (i *InputFloatWidget) OnChangeWithPrev(onChange func(oldValue, newValue float32)) *InputFloatWidget {
+	i.onChangeWithPrev = onChange
+	return i
+}
+
+// Step sets step of the +/- buttons (step) and of the buttons clicked
+// with Ctrl held (stepFast). The buttons are hidden if step is 0 (default).
+// The step is added to the value itself, not to the displayed text, so it works
+// even if the Format hides the fraction (e.g. "%.0f").
+// NOTE: the buttons aren't shown when ArrowNudge is used.
+func (i *InputFloatWidget) Step(step, stepFast float32) *InputFloatWidget {
+	i.step, i.stepFast = step, stepFast
+	return i
+}
+
+// Min sets the minimal value; edited values are clamped to it.
+func (i *InputFloatWidget) Min(value float32) *InputFloatWidget {
+	i.min = &value
+	return i
+}
+
+// Max sets the maximal value; edited values are clamped to it.
+func (i *InputFloatWidget) Max(value float32) *InputFloatWidget {
+	i.max = &value
+	return i
+}
+
+// ArrowNudge allows to change the value with keyboard while the input is focused:
+// Up/Down arrows increment/decrement the value by step and PageUp/PageDown by stepFast.
+// Single-line input doesn't use these keys for moving the text cursor,
+// so they don't conflict with editing.
+func (i *InputFloatWidget) ArrowNudge(step, stepFast float32) *InputFloatWidget {
+	i.nudgeStep, i.nudgeFast = step, stepFast
+	return i
+}
+
+// DragOnLabel makes the label a drag zone: dragging it horizontally changes
+// the value by speed per pixel (OnChange is called during the drag).
+// Clicking the field still allows to type the value.
+func (i *InputFloatWidget) DragOnLabel(speed float32) *InputFloatWidget {
+	i.dragSpeed = speed
+	return i
+}
+
+// Build implements Widget interface.
+func (i *InputFloatWidget) Build() {
+	if i.width != 0 {
+		PushItemWidth(i.width)
+		defer PopItemWidth()
+	}
+
+	if i.onChangeWithPrev != nil {
+		prevValue := *i.value
+		defer func() {
+			if *i.value != prevValue {
+				i.onChangeWithPrev(prevValue, *i.value)
+			}
+		}()
+	}
+
+	flags := i.flags
+	if i.readOnly {
+		flags |= InputTextFlagsReadOnly
+	}
+
+	buildInput := func() {
+		if (i.nudgeStep != 0 || i.nudgeFast != 0) && !i.readOnly {
+			i.buildWithNudge()
+		} else if imgui.InputFloatV(i.label, i.value, i.step, i.stepFast, i.format, int(flags)) {
+			*i.value = clampFloat32(*i.value, i.min, i.max)
+
+			if i.onChange != nil {
+				i.onChange()
+			}
+		}
+	}
+
+	if i.dragSpeed == 0 || i.readOnly {
+		buildInput()
+		return
+	}
+
+	if delta := buildWithLabelDrag(i.label, i.dragSpeed, buildInput); delta != 0 {
+		*i.value = clampFloat32(*i.value+delta, i.min, i.max)
+
+		if i.onChange != nil {
+			i.onChange()
+		}
+	}
+}
+
+// buildWithNudge builds the input as a text input to be able to
+// modify the text while the input is active (see ArrowNudge).
+func (i *InputFloatWidget) buildWithNudge() {
+	text := fmt.Sprintf(i.format, *i.value)
+
+	cb := func(data imgui.InputTextCallbackData) int32 {
+		var delta float32
+
+		switch {
+		case IsKeyPressed(KeyUp):
+			delta = i.nudgeStep
+		case IsKeyPressed(KeyDown):
+			delta = -i.nudgeStep
+		case IsKeyPressed(KeyPageUp):
+			delta = i.nudgeFast
+		case IsKeyPressed(KeyPageDown):
+			delta = -i.nudgeFast
+		}
+
+		if delta == 0 {
+			return 0
+		}
+
+		current, err := parseFormattedFloat(string(data.Buffer()), i.format)
+		if err != nil {
+			current = float64(*i.value)
+		}
+
+		data.DeleteBytes(0, len(data.Buffer()))
+		data.InsertBytes(0, []byte(fmt.Sprintf(i.format, float32(current)+delta)))
+
+		return 0
+	}
+
+	flags := i.flags | InputTextFlagsCharsScientific | InputTextFlagsCallbackAlways
+	if !imgui.InputTextV(i.label, &text, int(flags), cb) {
+		return
+	}
+
+	value, err := parseFormattedFloat(text, i.format)
+	if err != nil {
+		return
+	}
+
+	*i.value = clampFloat32(float32(value), i.min, i.max)
+
+	if i.onChange != nil {
+		i.onChange()
+	}
+}
+
+// floatVerbRegex matches a float verb of a fmt format (with its flags, width and precision).
+var floatVerbRegex = regexp.MustCompile(`%[-+ #0]*[0-9]*(\.[0-9]*)?[eEfFgG]`)
+
+// parseFormattedFloat parses text formatted by format (e.g. "12.50 kg" by "%.2f kg").
+// A plain number (e.g. typed by user without the suffix) is accepted as well.
+func parseFormattedFloat(text, format string) (float64, error) {
+	text = strings.TrimSpace(text)
+	if value, err := strconv.ParseFloat(text, 32); err == nil {
+		return value, nil
+	}
+
+	// fmt's scanning doesn't support precision (e.g. %.2f)
+	scanFormat := floatVerbRegex.ReplaceAllString(strings.TrimSpace(format), "%g")
+
+	var value float64
+	if _, err := fmt.Sscanf(text, scanFormat, &value); err != nil {
+		return 0, fmt.Errorf("parsing %q with format %q: %w", text, format, err)
+	}
+
+	return value, nil
+}
+
+var (
+	_ Widget     = &LabelWidget{}
+	_ Measurable = &LabelWidget{}
+)
+
+type LabelWidget struct {
+	label    string
+	fontInfo *FontInfo
+	color    color.Color
+	wrapped  bool
+	rtl      bool
+	onClick  func()
+	tooltip  string
+
+	typewriterID string
+	charsPerSec  float32
+	onComplete   func()
+}
+
+func Label(label string) *LabelWidget {
+	return &LabelWidget{
+		label:   tStr(label),
+		wrapped: false,
+	}
+}
+
+func Labelf(format string, args ...interface{}) *LabelWidget {
+	return Label(fmt.Sprintf(format, args...))
+}
+
+func (l *LabelWidget) Wrapped(wrapped bool) *LabelWidget {
+	l.wrapped = wrapped
+	return l
+}
+
+// RTL sets if the label should be aligned to the right (for right-to-left languages).
+// NOTE: the text is not reordered (there is no bidi support in imgui), so the RTL
+// text should be given in display order. Wrapped RTL labels are not right-aligned.
+func (l *LabelWidget) RTL(rtl bool) *LabelWidget {
+	l.rtl = rtl
+	return l
+}
+
+func (l *LabelWidget) Font(font *FontInfo) *LabelWidget {
+	l.fontInfo = font
+	return l
+}
+
+// OnClick sets callback called when the label is clicked with the left mouse button.
+// The hand cursor is shown while the label is hovered (e.g. for links).
+func (l *LabelWidget) OnClick(onClick func()) *LabelWidget {
+	l.onClick = onClick
+	return l
+}
+
+// Tooltip sets text shown in a tooltip when the label is hovered.
+// The tooltip uses the default style (not the label's font or color).
+func (l *LabelWidget) Tooltip(tooltip string) *LabelWidget {
+	l.tooltip = tStr(tooltip)
+	return l
+}
+
+// Color sets text color (nil means the default text color).
+func (l *LabelWidget) Color(col color.Color) *LabelWidget {
+	l.color = col
+	return l
+}
+
+// Typewriter reveals the text progressively (charsPerSec characters per second).
+// Clicking the label reveals the whole text at once.
+// The animation starts again when the text changes.
+func (l *LabelWidget) Typewriter(charsPerSec float32) *LabelWidget {
+	l.typewriterID = GenAutoID("Typewriter")
+	l.charsPerSec = charsPerSec
+
+	return l
+}
+
+// OnComplete sets callback called when the typewriter animation finishes (see Typewriter).
+func (l *LabelWidget) OnComplete(onComplete func()) *LabelWidget {
+	l.onComplete = onComplete
+	return l
+}
+
+// CalcWidth implements Measurable interface.
+// For the typewriter effect, it is a width of the whole text.
+func (l *LabelWidget) CalcWidth() float32 {
+	availableW, _ := GetAvailableRegion()
+	if l.rtl && !l.wrapped {
+		return availableW
+	}
+
+	if l.fontInfo != nil {
+		if PushFont(l.fontInfo) {
+			defer PopFont()
+		}
+	}
+
+	wrapWidth := float32(-1)
+	if l.wrapped {
+		wrapWidth = availableW
+	}
+
+	return calcTextWidth(l.label, wrapWidth)
+}
+
+// Build implements Widget interface.
+func (l *LabelWidget) Build() {
+	// deferred first, so it is built after the label's style is popped
+	if l.tooltip != "" {
+		defer func() {
+			if imgui.IsItemHovered() {
+				imgui.BeginTooltip()
+				imgui.Text(l.tooltip)
+				imgui.EndTooltip()
+			}
+		}()
+	}
+
+	if l.wrapped {
+		PushTextWrapPos()
+		defer PopTextWrapPos()
+	}
+
+	if l.fontInfo != nil {
+		if PushFont(l.fontInfo) {
+			defer PopFont()
 		}
+	}
 
-		SetNextWindowPos(imgui.GetItemRectMin().X, imgui.GetItemRectMax().Y)
-		imgui.BeginTooltip()
-		labels.Build()
-		imgui.EndTooltip()
+	if l.color != nil {
+		PushColorText(l.color)
+		defer PopStyleColor()
+	}
+
+	text := l.label
+
+	var state *typewriterState
+	if l.charsPerSec > 0 {
+		state = l.getTypewriterState()
+		text = state.update(l.charsPerSec, l.onComplete)
+	}
 
-		// Press enter will replace value string with first match candidate
-		if IsKeyPressed(KeyEnter) {
-			*i.value = state.autoCompleteCandidates[0].Str
-			state.autoCompleteCandidates = nil
+	if l.rtl && !l.wrapped {
+		l.buildRTL(text)
+	} else {
+		imgui.Text(text)
+	}
+
+	if state != nil && IsItemClicked(MouseButtonLeft) {
+		state.revealed = float64(len(state.text))
+	}
+
+	if l.onClick != nil {
+		if imgui.IsItemHovered() {
+			SetMouseCursor(MouseCursorHand)
+		}
+
+		if IsItemClicked(MouseButtonLeft) {
+			l.onClick()
 		}
 	}
 }
 
-var _ Widget = &InputIntWidget{}
+func (l *LabelWidget) getTypewriterState() (state *typewriterState) {
+	if s := Context.GetState(l.typewriterID); s == nil {
+		state = &typewriterState{}
+		Context.SetState(l.typewriterID, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*typewriterState)
+		Assert(isOk, "LabelWidget", "Build", "wrong state type recovered.")
+	}
 
-type InputIntWidget struct {
-	label    string
-	value    *int32
+	if string(state.text) != l.label {
+		*state = typewriterState{
+			text:     []rune(l.label),
+			lastTime: time.Now(),
+		}
+	}
+
+	return state
+}
+
+var _ Disposable = &typewriterState{}
+
+type typewriterState struct {
+	text []rune
+	// number of revealed characters
+	revealed   float64
+	lastTime   time.Time
+	isComplete bool
+}
+
+// Dispose implements Disposable interface.
+func (s *typewriterState) Dispose() {
+	// noop
+}
+
+// update advances the animation and returns the revealed part of the text.
+func (s *typewriterState) update(charsPerSec float32, onComplete func()) string {
+	now := time.Now()
+	s.revealed += float64(charsPerSec) * now.Sub(s.lastTime).Seconds()
+	s.lastTime = now
+
+	n := int(s.revealed)
+	if n < len(s.text) {
+		// keep frames rendering while animating
+		Update()
+		return string(s.text[:n])
+	}
+
+	if !s.isComplete {
+		s.isComplete = true
+
+		if onComplete != nil {
+			onComplete()
+		}
+	}
+
+	return string(s.text)
+}
+
+// buildRTL displays each line of the text aligned to the right.
+func (l *LabelWidget) buildRTL(text string) {
+	startX := imgui.CursorPosX()
+	availableW, _ := GetAvailableRegion()
+
+	imgui.BeginGroup()
+
+	for _, line := range strings.Split(text, "\n") {
+		lineW, _ := CalcTextSize(line)
+		if offset := availableW - lineW; offset > 0 {
+			imgui.SetCursorPos(imgui.Vec2{X: startX + offset, Y: imgui.CursorPos().Y})
+		}
+
+		imgui.Text(line)
+	}
+
+	imgui.EndGroup()
+}
+
+var _ Widget = &SelectableTextWidget{}
+
+// SelectableTextWidget is a label, which text could be selected and copied
+// (e.g. an error message to be pasted into a bug report).
+// It is a read-only input text styled to look like a label.
+type SelectableTextWidget struct {
+	id       string
+	text     string
 	width    float32
-	flags    InputTextFlags
-	onChange func()
+	fontInfo *FontInfo
+	color    color.Color
 }
 
-func InputInt(value *int32) *InputIntWidget {
-	return &InputIntWidget{
-		label:    GenAutoID("##InputInt"),
-		value:    value,
-		width:    0,
-		flags:    0,
-		onChange: nil,
+// SelectableText creates a new SelectableTextWidget.
+func SelectableText(text string) *SelectableTextWidget {
+	return &SelectableTextWidget{
+		id:   GenAutoID("##SelectableText"),
+		text: tStr(text),
 	}
 }
 
-func (i *InputIntWidget) Label(label string) *InputIntWidget {
-	i.label = tStr(label)
-	return i
+// ID allows to manually set widget's id.
+func (s *SelectableTextWidget) ID(id string) *SelectableTextWidget {
+	s.id = id
+	return s
 }
 
-func (i *InputIntWidget) Labelf(format string, args ...interface{}) *InputIntWidget {
-	return i.Label(fmt.Sprintf(format, args...))
+// Width sets widget's width (by default, it is a width of the text).
+func (s *SelectableTextWidget) Width(width float32) *SelectableTextWidget {
+	s.width = width
+	return s
 }
 
-func (i *InputIntWidget) Size(width float32) *InputIntWidget {
-	i.width = width
-	return i
+// Font sets text's font.
+func (s *SelectableTextWidget) Font(font *FontInfo) *SelectableTextWidget {
+	s.fontInfo = font
+	return s
 }
 
-func (i *InputIntWidget) Flags(flags InputTextFlags) *InputIntWidget {
-	i.flags = flags
-	return i
+// Color sets text color (nil means the default text color).
+func (s *SelectableTextWidget) Color(col color.Color) *SelectableTextWidget {
+	s.color = col
+	return s
 }
 
-func (i *InputIntWidget) OnChange(onChange func()) *InputIntWidget {
-	i.onChange = onChange
-	return i
+// Build implements Widget interface.
+func (s *SelectableTextWidget) Build() {
+	if s.fontInfo != nil {
+		if PushFont(s.fontInfo) {
+			defer PopFont()
+		}
+	}
+
+	if s.color != nil {
+		PushColorText(s.color)
+		defer PopStyleColor()
+	}
+
+	width := s.width
+	if width <= 0 {
+		textW, _ := CalcTextSize(s.text)
+		// leave space for the cursor
+		width = textW + 2
+	}
+
+	// look like a label
+	PushStyleColor(StyleColorFrameBg, color.Transparent)
+	PushFramePadding(0, 0)
+	imgui.PushStyleVarFloat(imgui.StyleVarFrameBorderSize, 0)
+
+	defer func() {
+		imgui.PopStyleVar()
+		PopStyle()
+		PopStyleColor()
+	}()
+
+	// the input needs a pointer, but it is read-only
+	text := s.text
+
+	imgui.PushItemWidth(width)
+	imgui.InputTextV(s.id, &text, int(InputTextFlagsReadOnly), nil)
+	imgui.PopItemWidth()
+}
+
+var _ Widget = &TextViewerWidget{}
+
+// TextViewerWidget is a bordered, scrollable region displaying a read-only
+// (e.g. license or log) text. Unlike read-only InputTextMultiline, it wraps
+// the lines. The text could be selected and copied (Ctrl+C copies wrapped lines)
+// or copied as a whole from the context menu (right click on the text).
+type TextViewerWidget struct {
+	id        string
+	text      string
+	width     float32
+	height    float32
+	wrapLines bool
+	monospace bool
+}
+
+// TextViewer creates a new TextViewerWidget.
+func TextViewer(text string) *TextViewerWidget {
+	return &TextViewerWidget{
+		id:        GenAutoID("TextViewer"),
+		text:      tStr(text),
+		width:     0,
+		height:    0,
+		wrapLines: true,
+		monospace: false,
+	}
+}
+
+// ID allows to manually set widget's id.
+func (t *TextViewerWidget) ID(id string) *TextViewerWidget {
+	t.id = id
+	return t
+}
+
+// Size sets size of the viewer.
+func (t *TextViewerWidget) Size(width, height float32) *TextViewerWidget {
+	t.width, t.height = width, height
+	return t
+}
+
+// WrapLines sets if long lines should be wrapped (default).
+// Otherwise, a horizontal scrollbar is shown.
+func (t *TextViewerWidget) WrapLines(wrap bool) *TextViewerWidget {
+	t.wrapLines = wrap
+	return t
+}
+
+// Monospace sets if the text should be displayed using a (system) monospace font.
+func (t *TextViewerWidget) Monospace(monospace bool) *TextViewerWidget {
+	t.monospace = monospace
+	return t
 }
 
 // Build implements Widget interface.
-func (i *InputIntWidget) Build() {
-	if i.width != 0 {
-		PushItemWidth(i.width)
-		defer PopItemWidth()
+func (t *TextViewerWidget) Build() {
+	flags := WindowFlagsNone
+	if !t.wrapLines {
+		flags = WindowFlagsHorizontalScrollbar
 	}
 
-	if imgui.InputIntV(i.label, i.value, 0, 100, int(i.flags)) && i.onChange != nil {
-		i.onChange()
+	if imgui.BeginChildV(t.id, imgui.Vec2{X: t.width, Y: t.height}, true, int(flags)) {
+		t.buildText()
 	}
+
+	imgui.EndChild()
 }
 
-var _ Widget = &InputFloatWidget{}
+// buildText builds the text inside of the child region as a read-only
+// multiline input (looking like a label), so that it could be selected.
+// The input is as high as the text, so the child region scrolls it.
+func (t *TextViewerWidget) buildText() {
+	if t.monospace && PushFont(getMonospaceFont()) {
+		defer PopFont()
+	}
 
-type InputFloatWidget struct {
-	label    string
-	value    *float32
-	width    float32
-	flags    InputTextFlags
-	format   string
-	onChange func()
+	text := t.text
+
+	availableW, _ := GetAvailableRegion()
+	width := availableW
+
+	if t.wrapLines {
+		text = t.wrappedText(availableW)
+	} else {
+		for _, line := range strings.Split(text, "\n") {
+			// leave space for the cursor
+			if lineW, _ := CalcTextSize(line); lineW+2 > width {
+				width = lineW + 2
+			}
+		}
+	}
+
+	height := float32(strings.Count(text, "\n")+1) * imgui.FontSize()
+
+	PushStyleColor(StyleColorFrameBg, color.Transparent)
+	PushFramePadding(0, 0)
+	imgui.PushStyleVarFloat(imgui.StyleVarFrameBorderSize, 0)
+
+	imgui.InputTextMultilineV(
+		t.id+"Text", &text, imgui.Vec2{X: width, Y: height},
+		int(InputTextFlagsReadOnly|InputTextFlagsNoHorizontalScroll), nil,
+	)
+
+	imgui.PopStyleVar()
+	PopStyle()
+	PopStyleColor()
+
+	if imgui.BeginPopupContextItemV(t.id+"Menu", int(MouseButtonRight)) {
+		if imgui.Selectable("Copy") {
+			Context.GetPlatform().SetClipboard(t.text)
+		}
+
+		imgui.EndPopup()
+	}
 }
 
-func InputFloat(value *float32) *InputFloatWidget {
-	return &InputFloatWidget{
-		label:    GenAutoID("##InputFloatWidget"),
-		width:    0,
-		value:    value,
-		format:   "%.3f",
-		flags:    0,
-		onChange: nil,
+// wrappedText returns the text wrapped to width.
+// It is cached in the widget's state until the text, font or width changes.
+func (t *TextViewerWidget) wrappedText(width float32) string {
+	var state *textViewerState
+	if s := Context.GetState(t.id); s == nil {
+		state = &textViewerState{}
+		Context.SetState(t.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*textViewerState)
+		Assert(isOk, "TextViewerWidget", "wrappedText", "got unexpected type of widget's state")
+	}
+
+	fontSize := imgui.FontSize()
+	if state.text != t.text || state.width != width || state.fontSize != fontSize || state.monospace != t.monospace {
+		measure := func(s string) float32 {
+			w, _ := CalcTextSize(s)
+			return w
+		}
+
+		state.text, state.width, state.fontSize, state.monospace = t.text, width, fontSize, t.monospace
+		state.wrapped = wrapText(t.text, measure, width)
 	}
+
+	return state.wrapped
 }
 
-func (i *InputFloatWidget) Label(label string) *InputFloatWidget {
-	i.label = tStr(label)
-	return i
+var _ Disposable = &textViewerState{}
+
+type textViewerState struct {
+	text      string
+	width     float32
+	fontSize  float32
+	monospace bool
+	wrapped   string
 }
 
-func (i *InputFloatWidget) Labelf(format string, args ...interface{}) *InputFloatWidget {
-	return i.Label(fmt.Sprintf(format, args...))
+// Dispose implements Disposable interface.
+func (s *textViewerState) Dispose() {
+	// noop
 }
 
-func (i *InputFloatWidget) Size(width float32) *InputFloatWidget {
-	i.width = width
-	return i
+// wrapText breaks lines of text longer than width at spaces (other spaces are kept).
+// A word wider than width is left on a line on its own.
+func wrapText(text string, measure func(string) float32, width float32) string {
+	lines := strings.Split(text, "\n")
+
+	for idx, line := range lines {
+		if measure(line) <= width {
+			continue
+		}
+
+		var (
+			wrapped []string
+			current string
+		)
+
+		for wordIdx, word := range strings.Split(line, " ") {
+			switch {
+			case wordIdx == 0:
+				current = word
+			case current != "" && measure(current+" "+word) > width:
+				wrapped = append(wrapped, current)
+				current = word
+			default:
+				current += " " + word
+			}
+		}
+
+		lines[idx] = strings.Join(append(wrapped, current), "\n")
+	}
+
+	return strings.Join(lines, "\n")
 }
 
-func (i *InputFloatWidget) Flags(flags InputTextFlags) *InputFloatWidget {
-	i.flags = flags
-	return i
+var _ Widget = &ParagraphWidget{}
+
+// ParagraphWidget displays a wrapped text. Unlike Label().Wrapped(true)
+// it is able to justify the text (distribute extra space between words
+// so that each line, except the last one, fills the whole width).
+type ParagraphWidget struct {
+	text    string
+	width   float32
+	justify bool
 }
 
-func (i *InputFloatWidget) Format(format string) *InputFloatWidget {
-	i.format = format
-	return i
+// Paragraph creates a new ParagraphWidget.
+func Paragraph(text string) *ParagraphWidget {
+	return &ParagraphWidget{
+		text:    tStr(text),
+		width:   0,
+		justify: false,
+	}
 }
 
-func (i *InputFloatWidget) OnChange(onChange func()) *InputFloatWidget {
-	i.onChange = onChange
-	return i
+// Paragraphf is a formatting version of Paragraph.
+func Paragraphf(format string, args ...interface{}) *ParagraphWidget {
+	return Paragraph(fmt.Sprintf(format, args...))
+}
+
+// Justify sets whether text should be justified.
+func (p *ParagraphWidget) Justify(justify bool) *ParagraphWidget {
+	p.justify = justify
+	return p
+}
+
+// Width sets paragraph's width. If width is 0 (default),
+// the available region width is used.
+func (p *ParagraphWidget) Width(width float32) *ParagraphWidget {
+	p.width = width
+	return p
 }
 
 // Build implements Widget interface.
-func (i *InputFloatWidget) Build() {
-	if i.width != 0 {
-		PushItemWidth(i.width)
-		defer PopItemWidth()
+func (p *ParagraphWidget) Build() {
+	width := p.width
+	if width <= 0 {
+		width, _ = GetAvailableRegion()
 	}
 
-	if imgui.InputFloatV(i.label, i.value, 0, 0, i.format, int(i.flags)) && i.onChange != nil {
-		i.onChange()
+	measure := func(s string) float32 {
+		w, _ := CalcTextSize(s)
+		return w
+	}
+
+	spaceW := measure(" ")
+
+	imgui.BeginGroup()
+
+	for _, line := range splitParagraphLines(p.text, measure, spaceW, width) {
+		gap := spaceW
+		if p.justify && !line.isLast && len(line.words) > 1 {
+			gap = (width - line.wordsWidth) / float32(len(line.words)-1)
+		}
+
+		if len(line.words) == 0 {
+			imgui.Text("")
+			continue
+		}
+
+		for idx, word := range line.words {
+			if idx > 0 {
+				imgui.SameLineV(0, gap)
+			}
+
+			imgui.Text(word)
+		}
 	}
+
+	imgui.EndGroup()
 }
 
-var _ Widget = &LabelWidget{}
+// paragraphLine represents a single line of ParagraphWidget.
+type paragraphLine struct {
+	words []string
+	// wordsWidth is a total width of words (without spaces).
+	wordsWidth float32
+	// isLast is true if the line is the last line of a paragraph.
+	isLast bool
+}
 
-type LabelWidget struct {
-	label    string
-	fontInfo *FontInfo
-	wrapped  bool
+// splitParagraphLines splits text into lines not wider than width.
+// Each "\n" starts a new paragraph. Words wider than width are placed
+// on their own line.
+func splitParagraphLines(text string, measure func(string) float32, spaceW, width float32) (lines []paragraphLine) {
+	for _, paragraph := range strings.Split(text, "\n") {
+		current := paragraphLine{}
+		var lineW float32
+
+		for _, word := range strings.Fields(paragraph) {
+			wordW := measure(word)
+
+			if len(current.words) > 0 && lineW+spaceW+wordW > width {
+				lines = append(lines, current)
+				current = paragraphLine{}
+				lineW = 0
+			}
+
+			if len(current.words) > 0 {
+				lineW += spaceW
+			}
+
+			current.words = append(current.words, word)
+			current.wordsWidth += wordW
+			lineW += wordW
+		}
+
+		current.isLast = true
+		lines = append(lines, current)
+	}
+
+	return lines
 }
 
-func Label(label string) *LabelWidget {
-	return &LabelWidget{
-		label:   tStr(label),
-		wrapped: false,
+var _ Widget = &InputStructFieldWidget{}
+
+// InputStructFieldWidget is an input bound (using reflection) to a field
+// of a struct. It displays InputText, InputInt or InputFloat depending on
+// the field's kind (string, integer or float) and writes the value back to the field.
+// NOTE: it is intended for prototyping (e.g. settings structs);
+// prefer InputText/InputInt/InputFloat with explicit pointers.
+type InputStructFieldWidget struct {
+	label     string
+	ptr       interface{}
+	fieldName string
+	width     float32
+	onChange  func()
+}
+
+// InputStructField creates a new InputStructFieldWidget bound to field fieldName
+// of the struct pointed by ptr.
+func InputStructField(ptr interface{}, fieldName string) *InputStructFieldWidget {
+	return &InputStructFieldWidget{
+		label:     GenAutoID("##" + fieldName),
+		ptr:       ptr,
+		fieldName: fieldName,
 	}
 }
 
-func Labelf(format string, args ...interface{}) *LabelWidget {
-	return Label(fmt.Sprintf(format, args...))
+// Label sets input's label.
+func (i *InputStructFieldWidget) Label(label string) *InputStructFieldWidget {
+	i.label = tStr(label)
+	return i
 }
 
-func (l *LabelWidget) Wrapped(wrapped bool) *LabelWidget {
-	l.wrapped = wrapped
-	return l
+// Size sets input's width.
+func (i *InputStructFieldWidget) Size(width float32) *InputStructFieldWidget {
+	i.width = width
+	return i
 }
 
-func (l *LabelWidget) Font(font *FontInfo) *LabelWidget {
-	l.fontInfo = font
-	return l
+// OnChange sets callback called when the field's value gets changed.
+func (i *InputStructFieldWidget) OnChange(onChange func()) *InputStructFieldWidget {
+	i.onChange = onChange
+	return i
+}
+
+// field returns reflect.Value of the bound field.
+func (i *InputStructFieldWidget) field() reflect.Value {
+	v := reflect.ValueOf(i.ptr)
+	Assert(v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct,
+		"InputStructFieldWidget", "Build", "ptr should be a non-nil pointer to a struct, got %T", i.ptr)
+
+	field := v.Elem().FieldByName(i.fieldName)
+	Assert(field.IsValid(), "InputStructFieldWidget", "Build", "%T has no field %q", i.ptr, i.fieldName)
+	Assert(field.CanSet(), "InputStructFieldWidget", "Build", "field %q of %T is unexported", i.fieldName, i.ptr)
+
+	return field
 }
 
 // Build implements Widget interface.
-func (l *LabelWidget) Build() {
-	if l.wrapped {
-		PushTextWrapPos()
-		defer PopTextWrapPos()
+func (i *InputStructFieldWidget) Build() {
+	field := i.field()
+	isChanged := false
+
+	switch field.Kind() {
+	case reflect.String:
+		value := field.String()
+		InputText(&value).Size(i.width).Label(i.label).Build()
+
+		if value != field.String() {
+			field.SetString(value)
+			isChanged = true
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		isChanged = i.buildInt(field)
+	case reflect.Float32, reflect.Float64:
+		// float64 values are written back only when edited (to not lose their precision)
+		value := float32(field.Float())
+		InputFloat(&value).Size(i.width).Label(i.label).OnChange(func() {
+			field.SetFloat(float64(value))
+			isChanged = true
+		}).Build()
+	default:
+		fatal("InputStructFieldWidget", "Build", "unsupported kind %s of field %q", field.Kind(), i.fieldName)
 	}
 
-	if l.fontInfo != nil {
-		if PushFont(l.fontInfo) {
-			defer PopFont()
-		}
+	if isChanged && i.onChange != nil {
+		i.onChange()
+	}
+}
+
+// buildInt builds an input for an integer field; it returns true if the field
+// was changed. Edited values are clamped to the range of the field's kind.
+// Values which don't fit in int32 (used by InputInt) are edited as a text.
+func (i *InputStructFieldWidget) buildInt(field reflect.Value) (isChanged bool) {
+	isUnsigned := field.Kind() >= reflect.Uint && field.Kind() <= reflect.Uint64
+
+	var (
+		value int32
+		fits  bool
+		text  string
+	)
+
+	if isUnsigned {
+		fits = field.Uint() <= math.MaxInt32
+		value, text = int32(field.Uint()), strconv.FormatUint(field.Uint(), 10)
+	} else {
+		fits = field.Int() >= math.MinInt32 && field.Int() <= math.MaxInt32
+		value, text = int32(field.Int()), strconv.FormatInt(field.Int(), 10)
+	}
+
+	if !fits {
+		InputText(&text).Size(i.width).Label(i.label).OnChange(func() {
+			bits := field.Type().Bits()
+			if isUnsigned {
+				if n, err := strconv.ParseUint(strings.TrimSpace(text), 10, bits); err == nil {
+					field.SetUint(n)
+					isChanged = true
+				}
+			} else if n, err := strconv.ParseInt(strings.TrimSpace(text), 10, bits); err == nil {
+				field.SetInt(n)
+				isChanged = true
+			}
+		}).Build()
+
+		return isChanged
 	}
 
-	imgui.Text(l.label)
+	minValue, maxValue := intKindRange(field.Kind())
+
+	InputInt(&value).Size(i.width).Label(i.label).Min(minValue).Max(maxValue).OnChange(func() {
+		if isUnsigned {
+			field.SetUint(uint64(value))
+		} else {
+			field.SetInt(int64(value))
+		}
+
+		isChanged = true
+	}).Build()
+
+	return isChanged
+}
+
+// intKindRange returns range of values of an integer kind limited to int32.
+func intKindRange(kind reflect.Kind) (minValue, maxValue int32) {
+	switch kind {
+	case reflect.Int8:
+		return math.MinInt8, math.MaxInt8
+	case reflect.Int16:
+		return math.MinInt16, math.MaxInt16
+	case reflect.Uint8:
+		return 0, math.MaxUint8
+	case reflect.Uint16:
+		return 0, math.MaxUint16
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return 0, math.MaxInt32
+	default:
+		return math.MinInt32, math.MaxInt32
+	}
 }
@@ -0,0 +1,1269 @@
+package giu
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_splitParagraphLines(t *testing.T) {
+	// every character is 1 unit wide
+	measure := func(s string) float32 {
+		return float32(len(s))
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		width    float32
+		expected []paragraphLine
+	}{
+		{"single line", "ab cd", 10, []paragraphLine{
+			{[]string{"ab", "cd"}, 4, true},
+		}},
+		{"wrapped", "ab cd ef", 5, []paragraphLine{
+			{[]string{"ab", "cd"}, 4, false},
+			{[]string{"ef"}, 2, true},
+		}},
+		{"word wider than width", "abcdefg hi", 5, []paragraphLine{
+			{[]string{"abcdefg"}, 7, false},
+			{[]string{"hi"}, 2, true},
+		}},
+		{"multiple paragraphs", "ab\ncd", 10, []paragraphLine{
+			{[]string{"ab"}, 2, true},
+			{[]string{"cd"}, 2, true},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, splitParagraphLines(test.text, measure, 1, test.width), "unexpected lines")
+		})
+	}
+}
+
+func Test_nextSelectableCandidate(t *testing.T) {
+	candidates := []AutoCompleteItem{
+		{Text: "header", Disabled: true},
+		{Text: "a"},
+		{Text: "b"},
+	}
+
+	tests := []struct {
+		name     string
+		current  int
+		backward bool
+		expected int
+	}{
+		{"first forward", -1, false, 1},
+		{"next", 1, false, 2},
+		{"wrap forward skipping disabled", 2, false, 1},
+		{"first backward", -1, true, 2},
+		{"wrap backward skipping disabled", 1, true, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, nextSelectableCandidate(candidates, test.current, test.backward), "unexpected candidate")
+		})
+	}
+
+	assert.Equal(t, -1, nextSelectableCandidate([]AutoCompleteItem{{Disabled: true}}, -1, false), "disabled candidate selected")
+}
+
+func Test_inputTextState_String(t *testing.T) {
+	state := &inputTextState{
+		autoCompleteCandidates: []AutoCompleteItem{{Text: "secret"}},
+	}
+
+	assert.Contains(t, state.String(), "secret", "non-sensitive state should contain candidates")
+
+	state.sensitive = true
+	assert.NotContains(t, state.String(), "secret", "sensitive state shouldn't contain candidates")
+	assert.Contains(t, state.String(), sensitiveRedacted, "sensitive state should be redacted")
+}
+
+func Test_findAutoCompleteMatches(t *testing.T) {
+	candidates := make([]AutoCompleteItem, 20)
+	for idx := range candidates {
+		candidates[idx] = AutoCompleteItem{Text: fmt.Sprintf("item %02d", idx)}
+	}
+
+	tests := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{"default", 5, 5},
+		{"more", 12, 12},
+		{"fewer", 2, 2},
+		{"more than matches", 50, 20},
+		{"unlimited", 0, 20},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			matches := findAutoCompleteMatches("item", candidates, tc.limit, nil)
+			assert.Len(t, matches, tc.expected)
+
+			// the limit keeps the best ranked matches
+			all := findAutoCompleteMatches("item", candidates, 0, nil)
+			assert.Equal(t, all[:len(matches)], matches)
+		})
+	}
+
+	assert.Equal(t, []AutoCompleteItem{{Text: "item 07"}}, findAutoCompleteMatches("item 07", candidates, 5, nil))
+}
+
+func Test_AutoCompleteMatcher(t *testing.T) {
+	candidates := []AutoCompleteItem{
+		{Text: "Commands", Disabled: true},
+		{Text: "cd"},
+		{Text: "chmod"},
+		{Text: "ls"},
+		{Text: "Cat"},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		matcher  AutoCompleteMatcher
+		limit    int
+		expected []AutoCompleteItem
+	}{
+		{"prefix", "c", PrefixAutoCompleteMatcher(true), 0, []AutoCompleteItem{{Text: "cd"}, {Text: "chmod"}}},
+		{
+			"case insensitive prefix", "c", PrefixAutoCompleteMatcher(false), 0,
+			[]AutoCompleteItem{{Text: "Commands", Disabled: true}, {Text: "cd"}, {Text: "chmod"}, {Text: "Cat"}},
+		},
+		{"limit", "c", PrefixAutoCompleteMatcher(false), 2, []AutoCompleteItem{{Text: "Commands", Disabled: true}, {Text: "cd"}}},
+		{"no match", "x", PrefixAutoCompleteMatcher(false), 0, nil},
+		{
+			"custom order and unknown candidate", "", func(input string, candidates []string) []string {
+				return []string{"ls", "pwd"}
+			}, 0,
+			[]AutoCompleteItem{{Text: "ls"}, {Text: "pwd"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, findAutoCompleteMatches(tc.input, candidates, tc.limit, tc.matcher))
+		})
+	}
+}
+
+func Test_ghostCompletionSuffix(t *testing.T) {
+	candidates := []AutoCompleteItem{
+		{Text: "apple pie", Disabled: true},
+		{Text: "banana"},
+		{Text: "Apple"},
+		{Text: "apricot"},
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"empty value", "", ""},
+		{"first prefix match skipping disabled", "ap", "ple"},
+		{"ignore case", "APR", "icot"},
+		{"no prefix match", "nana", ""},
+		{"already complete", "banana", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, ghostCompletionSuffix(test.value, candidates), "unexpected suffix")
+		})
+	}
+}
+
+func Test_InputIntWidget_DragOnLabel(t *testing.T) {
+	io := newTestContext(t)
+
+	var (
+		value            int32
+		changes          int
+		isHovered        bool
+		isActive         bool
+		itemMin, itemMax imgui.Vec2
+	)
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.Begin("drag on label")
+		InputInt(&value).Label("value").Size(100).DragOnLabel(1).OnChange(func() { changes++ }).Build()
+		itemMin, itemMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		isHovered, isActive = imgui.IsItemHovered(), imgui.IsItemActive()
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+
+	// the input (not the drag zone) is the last item;
+	// the hovered window is updated in the next frame
+	io.SetMousePosition(imgui.Vec2{X: itemMin.X + 10, Y: (itemMin.Y + itemMax.Y) / 2})
+	frame()
+	frame()
+	assert.True(t, isHovered, "the input should be the last item")
+
+	io.SetMouseButtonDown(int(MouseButtonLeft), true)
+	frame()
+	io.SetMouseButtonDown(int(MouseButtonLeft), false)
+	frame()
+	assert.True(t, isActive, "clicking the field should activate the input")
+	assert.Equal(t, int32(0), value, "clicking the field shouldn't change the value")
+
+	labelW, _ := CalcTextSize("value")
+	labelPos := imgui.Vec2{X: itemMax.X - labelW/2, Y: (itemMin.Y + itemMax.Y) / 2}
+
+	io.SetMousePosition(labelPos)
+	frame()
+	io.SetMouseButtonDown(int(MouseButtonLeft), true)
+	frame()
+
+	io.SetMousePosition(imgui.Vec2{X: labelPos.X + 10, Y: labelPos.Y})
+	frame()
+	io.SetMouseButtonDown(int(MouseButtonLeft), false)
+	frame()
+
+	assert.Equal(t, int32(10), value, "dragging the label should change the value")
+	assert.Greater(t, changes, 0, "OnChange should be called")
+}
+
+func Test_truncateRunes(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		n        int
+		expected string
+	}{
+		{"short", "abc", 5, "abc"},
+		{"exact", "abcde", 5, "abcde"},
+		{"pasted too long", "abcdefghij", 5, "abcde"},
+		{"multibyte", "zażółć gęślą", 5, "zażół"},
+		{"cjk", "日本語テキスト", 3, "日本語"},
+		{"empty", "", 3, ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, truncateRunes(tc.value, tc.n))
+			assert.Equal(t, len(tc.expected), runeLimitOffset([]byte(tc.value), tc.n))
+		})
+	}
+}
+
+// clickAt clicks the left mouse button at pos (frame builds and renders a frame).
+func clickAt(io imgui.IO, pos imgui.Vec2, frame func()) {
+	io.SetMousePosition(pos)
+	frame()
+	io.SetMouseButtonDown(int(MouseButtonLeft), true)
+	frame()
+	io.SetMouseButtonDown(int(MouseButtonLeft), false)
+	frame()
+}
+
+// stepButtonsPos returns centers of -/+ buttons of an InputInt/InputFloat
+// without label which rect is [itemMin, itemMax].
+func stepButtonsPos(itemMin, itemMax imgui.Vec2) (minus, plus imgui.Vec2) {
+	size := itemMax.Y - itemMin.Y
+	y := (itemMin.Y + itemMax.Y) / 2
+	plus = imgui.Vec2{X: itemMax.X - size/2, Y: y}
+	minus = imgui.Vec2{X: itemMax.X - size - imgui.CurrentStyle().ItemInnerSpacing().X - size/2, Y: y}
+
+	return minus, plus
+}
+
+func Test_InputIntWidget_Step(t *testing.T) {
+	io := newTestContext(t)
+
+	var (
+		value            int32 = 5
+		changes          int
+		itemMin, itemMax imgui.Vec2
+	)
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.Begin("input int step")
+		InputInt(&value).Label("##step").Size(200).Step(2, 10).OnChange(func() { changes++ }).Build()
+		itemMin, itemMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+
+	minus, plus := stepButtonsPos(itemMin, itemMax)
+
+	clickAt(io, plus, frame)
+	assert.Equal(t, int32(7), value, "+ should add the step")
+	assert.Equal(t, 1, changes, "OnChange should be called")
+
+	clickAt(io, minus, frame)
+	assert.Equal(t, int32(5), value, "- should subtract the step")
+	assert.Equal(t, 2, changes, "OnChange should be called")
+
+	// Ctrl+click uses the fast step
+	io.KeyPress(int(KeyLeftControl))
+	io.KeyCtrl(int(KeyLeftControl), int(KeyRightControl))
+	clickAt(io, plus, frame)
+	io.KeyRelease(int(KeyLeftControl))
+	io.KeyCtrl(int(KeyLeftControl), int(KeyRightControl))
+
+	assert.Equal(t, int32(15), value, "Ctrl+click should add the fast step")
+	assert.Equal(t, 3, changes, "OnChange should be called")
+}
+
+func Test_InputIntWidget_StepRepeatAndClamp(t *testing.T) {
+	io := newTestContext(t)
+
+	var (
+		value            int32 = 5
+		itemMin, itemMax imgui.Vec2
+	)
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.Begin("input int step repeat")
+		InputInt(&value).Label("##stepRepeat").Size(200).Step(1, 10).Max(9).Build()
+		itemMin, itemMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+
+	_, plus := stepButtonsPos(itemMin, itemMax)
+
+	// holding the button repeats the step after io.KeyRepeatDelay
+	io.SetMousePosition(plus)
+	frame()
+	io.SetMouseButtonDown(int(MouseButtonLeft), true)
+
+	for n := 0; n < 60; n++ {
+		frame()
+	}
+
+	io.SetMouseButtonDown(int(MouseButtonLeft), false)
+	frame()
+
+	assert.Equal(t, int32(9), value, "holding + should repeat the step up to Max")
+}
+
+func Test_InputTextMultilineWidget_OnChangeEx(t *testing.T) {
+	io := newTestContext(t)
+
+	var (
+		text, reportedText string
+		cursorPos, changes int
+	)
+
+	frame := func(focus bool) {
+		imgui.NewFrame()
+		imgui.Begin("on change ex")
+
+		if focus {
+			SetKeyboardFocusHere()
+		}
+
+		InputTextMultiline(&text).
+			Label("##onChangeEx").
+			OnChange(func() { changes++ }).
+			OnChangeEx(func(text string, cursorBytePos int) {
+				reportedText, cursorPos = text, cursorBytePos
+			}).
+			Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame(true)
+	frame(false)
+
+	io.AddInputCharacters("ab")
+	frame(false)
+
+	assert.Equal(t, "ab", reportedText, "callback should receive the updated text")
+	assert.Equal(t, 2, cursorPos, "unexpected cursor position")
+
+	// multibyte character
+	io.AddInputCharacters("ć")
+	frame(false)
+
+	assert.Equal(t, "abć", reportedText, "callback should receive the updated text")
+	assert.Equal(t, 4, cursorPos, "cursor position should be a byte offset")
+	assert.Equal(t, 2, changes, "OnChange should still be called")
+}
+
+func Test_clampInt32(t *testing.T) {
+	min, max := int32(-5), int32(10)
+
+	tests := []struct {
+		name     string
+		value    int32
+		min, max *int32
+		expected int32
+	}{
+		{"in range", 3, &min, &max, 3},
+		{"typed too big", 1000, &min, &max, 10},
+		{"typed too small", -1000, &min, &max, -5},
+		{"only min", 1000, &min, nil, 1000},
+		{"only max", -1000, nil, &max, -1000},
+		{"no bounds", 1000, nil, nil, 1000},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, clampInt32(tc.value, tc.min, tc.max))
+		})
+	}
+}
+
+func Test_clampFloat32(t *testing.T) {
+	min, max := float32(0), float32(1)
+
+	tests := []struct {
+		name     string
+		value    float32
+		min, max *float32
+		expected float32
+	}{
+		{"in range", 0.5, &min, &max, 0.5},
+		{"typed too big", 1.5, &min, &max, 1},
+		{"typed too small", -0.5, &min, &max, 0},
+		{"only min", 1.5, &min, nil, 1.5},
+		{"only max", -0.5, nil, &max, -0.5},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, clampFloat32(tc.value, tc.min, tc.max))
+		})
+	}
+}
+
+func Test_LabelWidget_Color(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("label color")
+
+	textColor := imgui.CurrentStyle().GetColor(imgui.StyleColorText)
+
+	Label("colored and wrapped label").Color(color.RGBA{R: 255, A: 255}).Wrapped(true).Build()
+	assert.Equal(t, textColor, imgui.CurrentStyle().GetColor(imgui.StyleColorText), "text color wasn't popped")
+
+	Label("default color").Color(nil).Build()
+	assert.Equal(t, textColor, imgui.CurrentStyle().GetColor(imgui.StyleColorText), "nil color changed the text color")
+
+	imgui.End()
+	imgui.Render()
+}
+
+func Test_LabelWidget_OnClick(t *testing.T) {
+	io := newTestContext(t)
+
+	clicks := 0
+
+	var labelMin, labelMax imgui.Vec2
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.Begin("label click")
+		Label("click me").OnClick(func() { clicks++ }).Build()
+		labelMin, labelMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+
+	// hover the label
+	io.SetMousePosition(imgui.Vec2{X: (labelMin.X + labelMax.X) / 2, Y: (labelMin.Y + labelMax.Y) / 2})
+	frame()
+	assert.Equal(t, 0, clicks, "callback called without click")
+
+	// right click is ignored
+	io.SetMouseButtonDown(int(MouseButtonRight), true)
+	frame()
+	io.SetMouseButtonDown(int(MouseButtonRight), false)
+	frame()
+	assert.Equal(t, 0, clicks, "callback called on right click")
+
+	io.SetMouseButtonDown(int(MouseButtonLeft), true)
+	frame()
+	assert.Equal(t, 1, clicks, "callback wasn't called on click")
+}
+
+func Test_isCharAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		allowed  string
+		denied   string
+		typed    string
+		expected string
+	}{
+		{"no filter", "", "", "a1 b2", "a1 b2"},
+		{"allowed digits", "0123456789", "", "a1b2 c3", "123"},
+		{"denied spaces", "", " \t", "a b\tc", "abc"},
+		{"allowed and denied", "abc123", "2", "a1b2c3d4", "a1bc3"},
+		{"multibyte", "ąęł", "", "zażółć gęślą", "łęą"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var result []rune
+
+			for _, c := range tc.typed {
+				if isCharAllowed(c, tc.allowed, tc.denied) {
+					result = append(result, c)
+				}
+			}
+
+			assert.Equal(t, tc.expected, string(result))
+		})
+	}
+}
+
+func Test_InputTextWidget_AllowedChars(t *testing.T) {
+	io := newTestContext(t)
+
+	var value string
+
+	userCbCalls := 0
+
+	frame := func(focus bool) {
+		imgui.NewFrame()
+		imgui.Begin("allowed chars")
+
+		if focus {
+			SetKeyboardFocusHere()
+		}
+
+		InputText(&value).
+			Label("##allowedChars").
+			AllowedChars("0123456789abcdef").
+			DeniedChars("0").
+			MaxLength(5).
+			Flags(InputTextFlagsCallbackCharFilter).
+			Callback(func(data imgui.InputTextCallbackData) int32 {
+				userCbCalls++
+				return 0
+			}).
+			Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame(true)
+	frame(false)
+
+	io.AddInputCharacters("x0a1-b2 c3d4e5")
+	frame(false)
+
+	assert.Equal(t, "a1b2c", value, "unexpected value")
+	assert.Equal(t, 5, userCbCalls, "user's callback should be called only for allowed chars within max length")
+}
+
+func Test_InputTextWidget_AutoCompleteKeys(t *testing.T) {
+	io := newTestContext(t)
+	for _, key := range []Key{KeyUp, KeyDown, KeyEnter, KeyEscape} {
+		io.KeyMap(int(key), int(key))
+	}
+
+	var value, other string
+
+	// focus: 1 - the input, 2 - the other input
+	frame := func(focus int) {
+		imgui.NewFrame()
+		imgui.Begin("autocomplete keys")
+
+		if focus == 1 {
+			SetKeyboardFocusHere()
+		}
+
+		InputText(&value).
+			Label("##autoCompleteKeys").
+			AutoComplete([]string{"apple", "apricot", "banana"}).
+			Build()
+
+		if focus == 2 {
+			SetKeyboardFocusHere()
+		}
+
+		InputText(&other).Label("##other").Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	press := func(key Key) {
+		io.KeyPress(int(key))
+		frame(0)
+		io.KeyRelease(int(key))
+		frame(0)
+	}
+
+	frame(1)
+	frame(0)
+
+	io.AddInputCharacters("ap")
+	frame(0)
+
+	// apple, apricot, apple (wraps around) and back to apricot
+	press(KeyDown)
+	press(KeyDown)
+	press(KeyDown)
+	press(KeyUp)
+	press(KeyEnter)
+
+	assert.Equal(t, "apricot", value, "Enter should choose the highlighted candidate")
+
+	// the input is still active; refocusing it from another widget selects its text
+	frame(2)
+	frame(0)
+	frame(1)
+	frame(0)
+
+	io.AddInputCharacters("ba")
+	frame(0)
+
+	// the candidates are still there, but the input isn't active
+	frame(2)
+	frame(0)
+
+	press(KeyEnter)
+	press(KeyEscape)
+
+	assert.Equal(t, "ba", value, "keys pressed in another widget shouldn't change the value")
+}
+
+func Test_LabelWidget_Tooltip(t *testing.T) {
+	io := newTestContext(t)
+
+	var labelMin, labelMax imgui.Vec2
+
+	// frame returns number of rendered windows
+	frame := func() int {
+		imgui.NewFrame()
+		imgui.Begin("label tooltip")
+		Label("hover me").Tooltip("extra context").Wrapped(true).Color(color.RGBA{R: 255, A: 255}).Build()
+		labelMin, labelMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		imgui.End()
+		imgui.Render()
+
+		return len(imgui.RenderedDrawData().CommandLists())
+	}
+
+	// the new window is hidden in its first frame
+	frame()
+
+	withoutTooltip := frame()
+	assert.Equal(t, withoutTooltip, frame(), "tooltip shown without hovering")
+
+	// hover the label (a new tooltip window is hidden in its first frame)
+	io.SetMousePosition(imgui.Vec2{X: (labelMin.X + labelMax.X) / 2, Y: (labelMin.Y + labelMax.Y) / 2})
+	frame()
+	assert.Equal(t, withoutTooltip+1, frame(), "tooltip wasn't shown on hover")
+
+	io.SetMousePosition(imgui.Vec2{X: -100, Y: -100})
+	frame()
+	assert.Equal(t, withoutTooltip, frame(), "tooltip shown after the mouse left")
+}
+
+func Test_InputTextWidget_CommitOnEnter(t *testing.T) {
+	io := newTestContext(t)
+	io.KeyMap(imgui.KeyEnter, int(KeyEnter))
+	io.KeyMap(imgui.KeyEnd, int(KeyEnd))
+
+	var value, other string
+
+	changes := 0
+
+	var commits [][2]string
+
+	// focus: 1 - the input, 2 - the other input
+	frame := func(focus int) {
+		imgui.NewFrame()
+		imgui.Begin("commit on enter")
+
+		if focus == 1 {
+			SetKeyboardFocusHere()
+		}
+
+		InputText(&value).
+			Label("##commitOnEnter").
+			CommitOnEnter().
+			OnChange(func() { changes++ }).
+			OnChangeWithPrev(func(oldValue, newValue string) {
+				commits = append(commits, [2]string{oldValue, newValue})
+			}).
+			Build()
+
+		if focus == 2 {
+			SetKeyboardFocusHere()
+		}
+
+		InputText(&other).Label("##other").Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame(1)
+	frame(0)
+
+	for _, c := range "abc" {
+		io.AddInputCharacters(string(c))
+		frame(0)
+	}
+
+	assert.Equal(t, "abc", value, "value should be updated on each keystroke")
+	assert.Equal(t, 0, changes, "OnChange called before Enter")
+
+	io.KeyPress(int(KeyEnter))
+	frame(0)
+	io.KeyRelease(int(KeyEnter))
+	frame(0)
+
+	assert.Equal(t, 1, changes, "OnChange should be called once on Enter")
+
+	// losing focus without changes doesn't commit
+	frame(1)
+	frame(0)
+	frame(2)
+	frame(0)
+
+	assert.Equal(t, 1, changes, "OnChange called without changes")
+
+	// losing focus after changes commits;
+	// focusing selects the whole text, so the cursor is moved to its end first
+	frame(1)
+	frame(0)
+	io.KeyPress(int(KeyEnd))
+	frame(0)
+	io.KeyRelease(int(KeyEnd))
+	frame(0)
+	io.AddInputCharacters("d")
+	frame(0)
+	frame(2)
+	frame(0)
+
+	assert.Equal(t, "abcd", value)
+	assert.Equal(t, 2, changes, "OnChange should be called when the input loses focus")
+	assert.Equal(t, [][2]string{{"", "abc"}, {"abc", "abcd"}}, commits, "OnChangeWithPrev should be called on commit only")
+}
+
+func Test_ReadOnly(t *testing.T) {
+	tests := []struct {
+		name string
+		// build builds the widget and returns its current value
+		build func(readOnly bool) interface{}
+	}{
+		{"InputText", func() func(bool) interface{} {
+			value := "text"
+			return func(readOnly bool) interface{} {
+				InputText(&value).Label("##readOnly").ReadOnly(readOnly).Build()
+				return value
+			}
+		}()},
+		{"InputTextMultiline", func() func(bool) interface{} {
+			value := "text"
+			return func(readOnly bool) interface{} {
+				InputTextMultiline(&value).Label("##readOnly").ReadOnly(readOnly).Build()
+				return value
+			}
+		}()},
+		{"InputInt", func() func(bool) interface{} {
+			var value int32 = 5
+			return func(readOnly bool) interface{} {
+				InputInt(&value).Label("##readOnly").ReadOnly(readOnly).Build()
+				return value
+			}
+		}()},
+		{"InputFloat", func() func(bool) interface{} {
+			var value float32 = 5
+			return func(readOnly bool) interface{} {
+				InputFloat(&value).Label("##readOnly").ReadOnly(readOnly).Build()
+				return value
+			}
+		}()},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		for _, readOnly := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s (read-only: %v)", tc.name, readOnly), func(t *testing.T) {
+				io := newTestContext(t)
+
+				var value interface{}
+
+				frame := func(focus bool) {
+					imgui.NewFrame()
+					imgui.Begin("read-only")
+
+					if focus {
+						SetKeyboardFocusHere()
+					}
+
+					value = tc.build(readOnly)
+
+					imgui.End()
+					imgui.Render()
+				}
+
+				frame(true)
+				frame(false)
+
+				initial := value
+
+				io.AddInputCharacters("12")
+				frame(false)
+
+				if readOnly {
+					assert.Equal(t, initial, value, "read-only value shouldn't be edited")
+				} else {
+					assert.NotEqual(t, initial, value, "value should be edited")
+				}
+			})
+		}
+	}
+}
+
+func Test_InputTextWidget_ReadOnly_Flags(t *testing.T) {
+	newTestContext(t)
+
+	value := "text"
+
+	var flags InputTextFlags
+
+	frame := func(focus bool) {
+		imgui.NewFrame()
+		imgui.Begin("read-only flags")
+
+		if focus {
+			SetKeyboardFocusHere()
+		}
+
+		InputText(&value).
+			Label("##readOnlyFlags").
+			Flags(InputTextFlagsCallbackAlways | InputTextFlagsCharsUppercase).
+			Callback(func(data imgui.InputTextCallbackData) int32 {
+				flags = InputTextFlags(data.Flags())
+				return 0
+			}).
+			ReadOnly(true).
+			Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame(true)
+	frame(false)
+
+	assert.NotZero(t, flags&InputTextFlagsReadOnly, "read-only flag should be set")
+	assert.NotZero(t, flags&InputTextFlagsCharsUppercase, "flags set by Flags should be kept")
+}
+
+func Test_intKindRange(t *testing.T) {
+	tests := []struct {
+		kind     reflect.Kind
+		min, max int32
+	}{
+		{reflect.Int8, -128, 127},
+		{reflect.Uint8, 0, 255},
+		{reflect.Int16, -32768, 32767},
+		{reflect.Uint16, 0, 65535},
+		{reflect.Int32, math.MinInt32, math.MaxInt32},
+		{reflect.Int64, math.MinInt32, math.MaxInt32},
+		{reflect.Uint64, 0, math.MaxInt32},
+	}
+
+	for _, tc := range tests {
+		minValue, maxValue := intKindRange(tc.kind)
+		assert.Equal(t, tc.min, minValue, "unexpected min of %s", tc.kind)
+		assert.Equal(t, tc.max, maxValue, "unexpected max of %s", tc.kind)
+	}
+}
+
+func Test_InputStructFieldWidget_Int(t *testing.T) {
+	io := newTestContext(t)
+
+	settings := struct {
+		Large int64
+		Small uint8
+	}{
+		Large: 1 << 40,
+		Small: 7,
+	}
+
+	changes := 0
+
+	frame := func(field string, focus bool) {
+		imgui.NewFrame()
+		imgui.Begin("struct field")
+
+		if focus {
+			SetKeyboardFocusHere()
+		}
+
+		InputStructField(&settings, field).
+			Label("##" + field).
+			OnChange(func() { changes++ }).
+			Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame("Large", false)
+	frame("Large", false)
+
+	assert.Equal(t, int64(1<<40), settings.Large, "value out of int32 range shouldn't be truncated")
+	assert.Equal(t, 0, changes, "OnChange called without any edit")
+
+	frame("Small", true)
+	frame("Small", false)
+
+	io.AddInputCharacters("300")
+	frame("Small", false)
+
+	assert.Equal(t, uint8(255), settings.Small, "value should be clamped to the field's range")
+	assert.Greater(t, changes, 0, "OnChange should be called")
+}
+
+func Test_InputFloatWidget_Step(t *testing.T) {
+	io := newTestContext(t)
+
+	var (
+		value            float32 = 5
+		changes          int
+		itemMin, itemMax imgui.Vec2
+	)
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.Begin("input float step")
+		InputFloat(&value).Label("##step").Size(200).Step(0.5, 10).OnChange(func() { changes++ }).Build()
+		itemMin, itemMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+
+	minus, plus := stepButtonsPos(itemMin, itemMax)
+
+	clickAt(io, plus, frame)
+	assert.Equal(t, float32(5.5), value, "+ should add the step")
+	assert.Equal(t, 1, changes, "OnChange should be called")
+
+	clickAt(io, minus, frame)
+	assert.Equal(t, float32(5), value, "- should subtract the step")
+	assert.Equal(t, 2, changes, "OnChange should be called")
+
+	// Ctrl+click uses the fast step
+	io.KeyPress(int(KeyLeftControl))
+	io.KeyCtrl(int(KeyLeftControl), int(KeyRightControl))
+	clickAt(io, plus, frame)
+	io.KeyRelease(int(KeyLeftControl))
+	io.KeyCtrl(int(KeyLeftControl), int(KeyRightControl))
+
+	assert.Equal(t, float32(15), value, "Ctrl+click should add the fast step")
+	assert.Equal(t, 3, changes, "OnChange should be called")
+}
+
+func Test_InputNumber_MinMax(t *testing.T) {
+	var (
+		intValue   int32
+		floatValue float32
+	)
+
+	tests := []struct {
+		name  string
+		build func()
+		value func() float64
+	}{
+		{
+			"InputInt",
+			func() { InputInt(&intValue).Label("##minMax").Min(-5).Max(10).Build() },
+			func() float64 { return float64(intValue) },
+		},
+		{
+			"InputFloat",
+			func() { InputFloat(&floatValue).Label("##minMax").Min(-5).Max(10).Build() },
+			func() float64 { return float64(floatValue) },
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			io := newTestContext(t)
+
+			frame := func(focus bool) {
+				imgui.NewFrame()
+				imgui.Begin("min max")
+
+				if focus {
+					SetKeyboardFocusHere()
+				}
+
+				tc.build()
+
+				imgui.End()
+				imgui.Render()
+			}
+
+			frame(true)
+			frame(false)
+
+			io.AddInputCharacters("999")
+			frame(false)
+
+			assert.Equal(t, float64(10), tc.value(), "value should be clamped to max")
+		})
+	}
+}
+
+func Test_InputTextWidget_MaxLength(t *testing.T) {
+	io := newTestContext(t)
+
+	var value string
+
+	frame := func(focus bool) {
+		imgui.NewFrame()
+		imgui.Begin("max length")
+
+		if focus {
+			SetKeyboardFocusHere()
+		}
+
+		InputText(&value).Label("##maxLength").MaxLength(3).Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame(true)
+	frame(false)
+
+	// more characters in a single frame (e.g. pasted)
+	io.AddInputCharacters("ąbćdę")
+	frame(false)
+
+	assert.Equal(t, "ąbć", value, "value should be truncated to max length")
+
+	// typed characters
+	for _, c := range "xy" {
+		io.AddInputCharacters(string(c))
+		frame(false)
+	}
+
+	assert.Equal(t, "ąbć", value, "characters beyond max length should be rejected")
+}
+
+func Test_InputTextWidget_ClearButton(t *testing.T) {
+	io := newTestContext(t)
+
+	value := "abc"
+	changes := 0
+
+	var (
+		start            imgui.Vec2
+		itemW            float32
+		labelMin, center imgui.Vec2
+	)
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.SetNextWindowSize(imgui.Vec2{X: 400, Y: 300})
+		imgui.Begin("clear button")
+
+		start = imgui.CursorScreenPos()
+		itemW = imgui.CalcItemWidth()
+
+		InputText(&value).Label("Name").ClearButton().OnChange(func() { changes++ }).Build()
+		labelMin = imgui.GetItemRectMin()
+		center = imgui.Vec2{X: start.X + itemW - 5, Y: start.Y + frameHeight()/2}
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+	frame()
+
+	innerSpacing := imgui.CurrentStyle().ItemInnerSpacing().X
+	assert.InDelta(t, start.X+itemW+innerSpacing, labelMin.X, 0.5, "label should be after the clear button")
+
+	clickAt(io, center, frame)
+	assert.Equal(t, "", value, "clear button should clear the value")
+	assert.Equal(t, 1, changes, "clear button should call OnChange")
+
+	assert.InDelta(t, start.X+itemW+innerSpacing, labelMin.X, 0.5, "label shouldn't move when the button is hidden")
+}
+
+type testClipboard struct {
+	text string
+}
+
+func (c *testClipboard) Text() (string, error) {
+	return c.text, nil
+}
+
+func (c *testClipboard) SetText(text string) {
+	c.text = text
+}
+
+func Test_InputTextWidget_MaxLengthPaste(t *testing.T) {
+	io := newTestContext(t)
+	io.SetClipboard(&testClipboard{text: "XYZ"})
+	io.KeyMap(imgui.KeyHome, int(KeyHome))
+	io.KeyMap(imgui.KeyRightArrow, int(KeyRight))
+	io.KeyMap(imgui.KeyV, int(KeyV))
+
+	value := "abcd"
+
+	frame := func(focus bool) {
+		imgui.NewFrame()
+		imgui.Begin("max length paste")
+
+		if focus {
+			SetKeyboardFocusHere()
+		}
+
+		InputText(&value).Label("##maxLengthPaste").MaxLength(6).Build()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	press := func(key Key) {
+		io.KeyPress(int(key))
+		frame(false)
+		io.KeyRelease(int(key))
+		frame(false)
+	}
+
+	frame(true)
+	frame(false)
+
+	// move the cursor after "ab"
+	press(KeyHome)
+	press(KeyRight)
+	press(KeyRight)
+
+	io.KeyPress(int(KeyLeftControl))
+	io.KeyCtrl(int(KeyLeftControl), int(KeyRightControl))
+	press(KeyV)
+	io.KeyRelease(int(KeyLeftControl))
+	io.KeyCtrl(int(KeyLeftControl), int(KeyRightControl))
+
+	assert.Equal(t, "abXYcd", value, "pasted text should be cut at the limit, keeping the text after the cursor")
+
+	// typing at the limit is rejected
+	io.AddInputCharacters("w")
+	frame(false)
+
+	assert.Equal(t, "abXYcd", value, "characters beyond max length should be rejected")
+}
+
+func Test_parseFormattedFloat(t *testing.T) {
+	tests := []struct {
+		text, format string
+		expected     float64
+		isErr        bool
+	}{
+		{"12.50", "%.2f", 12.5, false},
+		{" 3 ", "%.0f", 3, false},
+		{"12.50 kg", "%.2f kg", 12.5, false},
+		{"$-1.5", "$%.1f", -1.5, false},
+		{"50.0%", "%.1f%%", 50, false},
+		{"1.5e+02 m", "%e m", 150, false},
+		{"7", "%.2f kg", 7, false},
+		{"abc kg", "%.2f kg", 0, true},
+	}
+
+	for _, tc := range tests {
+		value, err := parseFormattedFloat(tc.text, tc.format)
+		if tc.isErr {
+			assert.Error(t, err, "%q with format %q should fail", tc.text, tc.format)
+			continue
+		}
+
+		assert.NoError(t, err, "unexpected error for %q with format %q", tc.text, tc.format)
+		assert.InDelta(t, tc.expected, value, 1e-6, "unexpected value of %q with format %q", tc.text, tc.format)
+	}
+}
+
+func Test_wrapText(t *testing.T) {
+	// every character is 1 unit wide
+	measure := func(s string) float32 {
+		return float32(len(s))
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		width    float32
+		expected string
+	}{
+		{"short", "ab cd", 10, "ab cd"},
+		{"wrapped", "ab cd ef", 5, "ab cd\nef"},
+		{"spaces kept", "ab  cd ef", 6, "ab  cd\nef"},
+		{"word wider than width", "abcdefg hi", 5, "abcdefg\nhi"},
+		{"multiple lines", "ab cd\nef gh", 3, "ab\ncd\nef\ngh"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, wrapText(tc.text, measure, tc.width))
+		})
+	}
+}
+
+func Test_TextViewerWidget(t *testing.T) {
+	io := newTestContext(t)
+
+	var viewerMin, viewerMax imgui.Vec2
+
+	isTextActive := false
+
+	frame := func() {
+		imgui.NewFrame()
+		imgui.Begin("text viewer")
+		TextViewer("a long line of text which should be wrapped\nsecond line").Size(100, 100).Build()
+		viewerMin, viewerMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		TextViewer("not wrapped").WrapLines(false).Build()
+		isTextActive = imgui.IsAnyItemActive()
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame()
+
+	// the text is selectable, so clicking it activates the (read-only) input
+	io.SetMousePosition(imgui.Vec2{X: viewerMin.X + 20, Y: viewerMin.Y + 10})
+	frame()
+	frame()
+	io.SetMouseButtonDown(int(MouseButtonLeft), true)
+	frame()
+	frame()
+
+	assert.True(t, isTextActive, "clicking the text should activate it")
+	assert.Less(t, viewerMax.X-viewerMin.X, float32(101), "wrapped viewer should keep its size")
+}
@@ -11,6 +11,8 @@ import (
 
 type Texture struct {
 	id imgui.TextureID
+	// size of the texture's image (zero if unknown)
+	size image.Point
 }
 
 type loadImageResult struct {
@@ -35,7 +37,7 @@ func NewTextureFromRgba(rgba image.Image, loadCallback func(*Texture)) {
 			panic(fmt.Sprintf("giu: NewTextureFromRgba: error loading texture: %v", tid.err))
 		}
 
-		texture := Texture{id: tid.id}
+		texture := Texture{id: tid.id, size: rgba.Bounds().Size()}
 
 		// Set finalizer
 		runtime.SetFinalizer(&texture, (*Texture).release)
@@ -50,6 +52,12 @@ func ToTexture(textureID imgui.TextureID) *Texture {
 	return &Texture{id: textureID}
 }
 
+// Size returns size of the texture's image in pixels.
+// It returns zero size if the size is unknown (e.g. texture created by ToTexture).
+func (t *Texture) Size() image.Point {
+	return t.size
+}
+
 func (t *Texture) release() {
 	Update()
 	mainthread.Call(func() {
@@ -0,0 +1,464 @@
+package giu
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AllenDang/imgui-go"
+)
+
+// styleColorNames are names of style colors used in theme files.
+var styleColorNames = map[StyleColorID]string{
+	StyleColorText:                  "Text",
+	StyleColorTextDisabled:          "TextDisabled",
+	StyleColorWindowBg:              "WindowBg",
+	StyleColorChildBg:               "ChildBg",
+	StyleColorPopupBg:               "PopupBg",
+	StyleColorBorder:                "Border",
+	StyleColorBorderShadow:          "BorderShadow",
+	StyleColorFrameBg:               "FrameBg",
+	StyleColorFrameBgHovered:        "FrameBgHovered",
+	StyleColorFrameBgActive:         "FrameBgActive",
+	StyleColorTitleBg:               "TitleBg",
+	StyleColorTitleBgActive:         "TitleBgActive",
+	StyleColorTitleBgCollapsed:      "TitleBgCollapsed",
+	StyleColorMenuBarBg:             "MenuBarBg",
+	StyleColorScrollbarBg:           "ScrollbarBg",
+	StyleColorScrollbarGrab:         "ScrollbarGrab",
+	StyleColorScrollbarGrabHovered:  "ScrollbarGrabHovered",
+	StyleColorScrollbarGrabActive:   "ScrollbarGrabActive",
+	StyleColorCheckMark:             "CheckMark",
+	StyleColorSliderGrab:            "SliderGrab",
+	StyleColorSliderGrabActive:      "SliderGrabActive",
+	StyleColorButton:                "Button",
+	StyleColorButtonHovered:         "ButtonHovered",
+	StyleColorButtonActive:          "ButtonActive",
+	StyleColorHeader:                "Header",
+	StyleColorHeaderHovered:         "HeaderHovered",
+	StyleColorHeaderActive:          "HeaderActive",
+	StyleColorSeparator:             "Separator",
+	StyleColorSeparatorHovered:      "SeparatorHovered",
+	StyleColorSeparatorActive:       "SeparatorActive",
+	StyleColorResizeGrip:            "ResizeGrip",
+	StyleColorResizeGripHovered:     "ResizeGripHovered",
+	StyleColorResizeGripActive:      "ResizeGripActive",
+	StyleColorTab:                   "Tab",
+	StyleColorTabHovered:            "TabHovered",
+	StyleColorTabActive:             "TabActive",
+	StyleColorTabUnfocused:          "TabUnfocused",
+	StyleColorTabUnfocusedActive:    "TabUnfocusedActive",
+	StyleColorPlotLines:             "PlotLines",
+	StyleColorPlotLinesHovered:      "PlotLinesHovered",
+	StyleColorPlotHistogram:         "PlotHistogram",
+	StyleColorPlotHistogramHovered:  "PlotHistogramHovered",
+	StyleColorTableHeaderBg:         "TableHeaderBg",
+	StyleColorTableBorderStrong:     "TableBorderStrong",
+	StyleColorTableBorderLight:      "TableBorderLight",
+	StyleColorTableRowBg:            "TableRowBg",
+	StyleColorTableRowBgAlt:         "TableRowBgAlt",
+	StyleColorTextSelectedBg:        "TextSelectedBg",
+	StyleColorDragDropTarget:        "DragDropTarget",
+	StyleColorNavHighlight:          "NavHighlight",
+	StyleColorNavWindowingHighlight: "NavWindowingHighlight",
+	StyleColorNavWindowingDimBg:     "NavWindowingDimBg",
+	StyleColorModalWindowDimBg:      "ModalWindowDimBg",
+}
+
+// styleVarNames are names of style vars used in theme files.
+var styleVarNames = map[StyleVarID]string{
+	StyleVarAlpha:               "Alpha",
+	StyleVarDisabledAlpha:       "DisabledAlpha",
+	StyleVarWindowPadding:       "WindowPadding",
+	StyleVarWindowRounding:      "WindowRounding",
+	StyleVarWindowBorderSize:    "WindowBorderSize",
+	StyleVarWindowMinSize:       "WindowMinSize",
+	StyleVarWindowTitleAlign:    "WindowTitleAlign",
+	StyleVarChildRounding:       "ChildRounding",
+	StyleVarChildBorderSize:     "ChildBorderSize",
+	StyleVarPopupRounding:       "PopupRounding",
+	StyleVarPopupBorderSize:     "PopupBorderSize",
+	StyleVarFramePadding:        "FramePadding",
+	StyleVarFrameRounding:       "FrameRounding",
+	StyleVarFrameBorderSize:     "FrameBorderSize",
+	StyleVarItemSpacing:         "ItemSpacing",
+	StyleVarItemInnerSpacing:    "ItemInnerSpacing",
+	StyleVarIndentSpacing:       "IndentSpacing",
+	StyleVarScrollbarSize:       "ScrollbarSize",
+	StyleVarScrollbarRounding:   "ScrollbarRounding",
+	StyleVarGrabMinSize:         "GrabMinSize",
+	StyleVarGrabRounding:        "GrabRounding",
+	StyleVarTabRounding:         "TabRounding",
+	StyleVarButtonTextAlign:     "ButtonTextAlign",
+	StyleVarSelectableTextAlign: "SelectableTextAlign",
+}
+
+// themeFile is a theme file's format, e.g.:
+//
+//	{
+//	  "colors": {"WindowBg": "#1c262bff", "Text": "#f2f5fa"},
+//	  "styles": {"FrameRounding": 4, "FramePadding": {"x": 8, "y": 4}}
+//	}
+type themeFile struct {
+	Colors map[string]string          `json:"colors"`
+	Styles map[string]json.RawMessage `json:"styles"`
+}
+
+type themeVec2 struct {
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+}
+
+// parseHexColor parses colors in format #RRGGBB or #RRGGBBAA.
+func parseHexColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+
+	if len(hex) != 8 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q: expected #RRGGBB or #RRGGBBAA", s)
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q: %w", s, err)
+	}
+
+	return color.RGBA{
+		R: uint8(v >> 24),
+		G: uint8(v >> 16),
+		B: uint8(v >> 8),
+		A: uint8(v),
+	}, nil
+}
+
+// loadTheme reads a theme file into a new StyleSetter.
+// Unknown fields, colors or style vars are reported as errors.
+func loadTheme(r io.Reader) (*StyleSetter, error) {
+	var theme themeFile
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&theme); err != nil {
+		return nil, fmt.Errorf("error decoding theme: %w", err)
+	}
+
+	ss := Style()
+
+	for name, value := range theme.Colors {
+		id, ok := findStyleColorID(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown style color %q", name)
+		}
+
+		col, err := parseHexColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("style color %s: %w", name, err)
+		}
+
+		ss.SetColor(id, col)
+	}
+
+	for name, value := range theme.Styles {
+		id, ok := findStyleVarID(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown style var %q", name)
+		}
+
+		if id.IsVec2() {
+			var vec themeVec2
+			if err := json.Unmarshal(value, &vec); err != nil {
+				return nil, fmt.Errorf("style var %s: expected {\"x\": ..., \"y\": ...}: %w", name, err)
+			}
+
+			ss.SetStyle(id, vec.X, vec.Y)
+
+			continue
+		}
+
+		var f float32
+		if err := json.Unmarshal(value, &f); err != nil {
+			return nil, fmt.Errorf("style var %s: expected a number: %w", name, err)
+		}
+
+		ss.SetStyleFloat(id, f)
+	}
+
+	return ss, nil
+}
+
+// FromJSON reads colors and styles from a theme in JSON format
+// (see ToJSON) and sets them in the setter.
+// Colors and styles are keyed by their names (e.g. "WindowBg", "FramePadding"),
+// colors are hex strings (#RRGGBB or #RRGGBBAA), vec2 styles are {"x": ..., "y": ...}
+// objects and float styles are numbers.
+// Unknown names cause an error; the setter isn't modified in such a case.
+func (ss *StyleSetter) FromJSON(r io.Reader) (*StyleSetter, error) {
+	theme, err := loadTheme(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, col := range theme.colors {
+		ss.colors[id] = col
+	}
+
+	for id, value := range theme.styles {
+		ss.styles[id] = value
+	}
+
+	return ss, nil
+}
+
+// ToJSON writes setter's colors and styles as a theme in JSON format (see FromJSON).
+func (ss *StyleSetter) ToJSON(w io.Writer) error {
+	theme := themeFile{
+		Colors: make(map[string]string, len(ss.colors)),
+		Styles: make(map[string]json.RawMessage, len(ss.styles)),
+	}
+
+	for id, col := range ss.colors {
+		name, ok := styleColorNames[id]
+		if !ok {
+			return fmt.Errorf("unknown style color %d", id)
+		}
+
+		c, _ := color.RGBAModel.Convert(col).(color.RGBA)
+		theme.Colors[name] = fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+	}
+
+	for id, value := range ss.styles {
+		name, ok := styleVarNames[id]
+		if !ok {
+			return fmt.Errorf("unknown style var %d", id)
+		}
+
+		// the same conversions as in StyleSetter.Build
+		var v interface{}
+
+		switch typed := value.(type) {
+		case imgui.Vec2:
+			v = themeVec2{X: typed.X, Y: typed.Y}
+			if !id.IsVec2() {
+				v = typed.X
+			}
+		case float32:
+			v = typed
+			if id.IsVec2() {
+				v = themeVec2{X: typed, Y: typed}
+			}
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("style var %s: %w", name, err)
+		}
+
+		theme.Styles[name] = data
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(theme); err != nil {
+		return fmt.Errorf("error encoding theme: %w", err)
+	}
+
+	return nil
+}
+
+// loadThemeFile loads theme from the file (see loadTheme).
+func loadThemeFile(path string) (theme *StyleSetter, err error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error opening theme file %s: %w", path, err)
+	}
+
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			theme, err = nil, fmt.Errorf("error closing theme file %s: %w", path, closeErr)
+		}
+	}()
+
+	theme, err = loadTheme(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return theme, nil
+}
+
+func findStyleColorID(name string) (StyleColorID, bool) {
+	for id, n := range styleColorNames {
+		if n == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+func findStyleVarID(name string) (StyleVarID, bool) {
+	for id, n := range styleVarNames {
+		if n == name {
+			return id, true
+		}
+	}
+
+	return 0, false
+}
+
+// ColorDiff is a difference of a style color between two themes (see DiffThemes).
+// A is nil if the color isn't set in the first theme, B if it isn't set in the second one.
+type ColorDiff struct {
+	ID   StyleColorID
+	A, B color.Color
+}
+
+// DiffThemes returns colors which differ between a and b (sorted by id).
+// Colors are compared by their RGBA values.
+func DiffThemes(a, b map[StyleColorID]color.Color) []ColorDiff {
+	var result []ColorDiff
+
+	for id, colA := range a {
+		colB, ok := b[id]
+		if !ok || !isColorEqual(colA, colB) {
+			result = append(result, ColorDiff{ID: id, A: colA, B: colB})
+		}
+	}
+
+	for id, colB := range b {
+		if _, ok := a[id]; !ok {
+			result = append(result, ColorDiff{ID: id, B: colB})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// MergeThemes returns a new theme containing colors of base overridden by colors
+// set in override (e.g. a brand accent on top of a dark theme).
+// Neither of the arguments is modified.
+func MergeThemes(base, override map[StyleColorID]color.Color) map[StyleColorID]color.Color {
+	result := make(map[StyleColorID]color.Color, len(base)+len(override))
+
+	for id, col := range base {
+		result[id] = col
+	}
+
+	for id, col := range override {
+		result[id] = col
+	}
+
+	return result
+}
+
+func isColorEqual(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+
+	return r1 == r2 && g1 == g2 && b1 == b2 && a1 == a2
+}
+
+// rounding of windows, frames and grabs in StyleDark and StyleLight themes.
+const (
+	styleDarkWindowRounding  = 2
+	styleDarkFrameRounding   = 4
+	styleLightWindowRounding = 6
+	styleLightFrameRounding  = 3
+)
+
+// styleDarkColors are colors of StyleDark (the same as giu's default style).
+var styleDarkColors = map[StyleColorID]vec4Color{
+	StyleColorText:           {X: 0.95, Y: 0.96, Z: 0.98, W: 1.00},
+	StyleColorTextDisabled:   {X: 0.36, Y: 0.42, Z: 0.47, W: 1.00},
+	StyleColorWindowBg:       {X: 0.11, Y: 0.15, Z: 0.17, W: 1.00},
+	StyleColorChildBg:        {X: 0.15, Y: 0.18, Z: 0.22, W: 1.00},
+	StyleColorPopupBg:        {X: 0.08, Y: 0.08, Z: 0.08, W: 0.94},
+	StyleColorBorder:         {X: 0.08, Y: 0.10, Z: 0.12, W: 1.00},
+	StyleColorFrameBg:        {X: 0.20, Y: 0.25, Z: 0.29, W: 1.00},
+	StyleColorFrameBgHovered: {X: 0.12, Y: 0.20, Z: 0.28, W: 1.00},
+	StyleColorFrameBgActive:  {X: 0.09, Y: 0.12, Z: 0.14, W: 1.00},
+	StyleColorTitleBg:        {X: 0.09, Y: 0.12, Z: 0.14, W: 0.65},
+	StyleColorTitleBgActive:  {X: 0.08, Y: 0.10, Z: 0.12, W: 1.00},
+	StyleColorMenuBarBg:      {X: 0.15, Y: 0.18, Z: 0.22, W: 1.00},
+	StyleColorCheckMark:      {X: 0.28, Y: 0.56, Z: 1.00, W: 1.00},
+	StyleColorSliderGrab:     {X: 0.28, Y: 0.56, Z: 1.00, W: 1.00},
+	StyleColorButton:         {X: 0.20, Y: 0.25, Z: 0.29, W: 1.00},
+	StyleColorButtonHovered:  {X: 0.28, Y: 0.56, Z: 1.00, W: 1.00},
+	StyleColorButtonActive:   {X: 0.06, Y: 0.53, Z: 0.98, W: 1.00},
+	StyleColorHeader:         {X: 0.20, Y: 0.25, Z: 0.29, W: 0.55},
+	StyleColorHeaderHovered:  {X: 0.26, Y: 0.59, Z: 0.98, W: 0.80},
+	StyleColorHeaderActive:   {X: 0.26, Y: 0.59, Z: 0.98, W: 1.00},
+	StyleColorTab:            {X: 0.11, Y: 0.15, Z: 0.17, W: 1.00},
+	StyleColorTabHovered:     {X: 0.26, Y: 0.59, Z: 0.98, W: 0.80},
+	StyleColorTabActive:      {X: 0.20, Y: 0.25, Z: 0.29, W: 1.00},
+	StyleColorTextSelectedBg: {X: 0.26, Y: 0.59, Z: 0.98, W: 0.35},
+}
+
+// styleLightColors are colors of StyleLight.
+var styleLightColors = map[StyleColorID]vec4Color{
+	StyleColorText:           {X: 0.00, Y: 0.00, Z: 0.00, W: 1.00},
+	StyleColorTextDisabled:   {X: 0.60, Y: 0.60, Z: 0.60, W: 1.00},
+	StyleColorWindowBg:       {X: 0.94, Y: 0.94, Z: 0.94, W: 1.00},
+	StyleColorChildBg:        {X: 0.98, Y: 0.98, Z: 0.98, W: 1.00},
+	StyleColorPopupBg:        {X: 1.00, Y: 1.00, Z: 1.00, W: 0.98},
+	StyleColorBorder:         {X: 0.00, Y: 0.00, Z: 0.00, W: 0.30},
+	StyleColorFrameBg:        {X: 1.00, Y: 1.00, Z: 1.00, W: 1.00},
+	StyleColorFrameBgHovered: {X: 0.26, Y: 0.59, Z: 0.98, W: 0.40},
+	StyleColorFrameBgActive:  {X: 0.26, Y: 0.59, Z: 0.98, W: 0.67},
+	StyleColorTitleBg:        {X: 0.96, Y: 0.96, Z: 0.96, W: 1.00},
+	StyleColorTitleBgActive:  {X: 0.82, Y: 0.82, Z: 0.82, W: 1.00},
+	StyleColorMenuBarBg:      {X: 0.86, Y: 0.86, Z: 0.86, W: 1.00},
+	StyleColorCheckMark:      {X: 0.26, Y: 0.59, Z: 0.98, W: 1.00},
+	StyleColorSliderGrab:     {X: 0.26, Y: 0.59, Z: 0.98, W: 0.78},
+	StyleColorButton:         {X: 0.26, Y: 0.59, Z: 0.98, W: 0.40},
+	StyleColorButtonHovered:  {X: 0.26, Y: 0.59, Z: 0.98, W: 1.00},
+	StyleColorButtonActive:   {X: 0.06, Y: 0.53, Z: 0.98, W: 1.00},
+	StyleColorHeader:         {X: 0.26, Y: 0.59, Z: 0.98, W: 0.31},
+	StyleColorHeaderHovered:  {X: 0.26, Y: 0.59, Z: 0.98, W: 0.80},
+	StyleColorHeaderActive:   {X: 0.26, Y: 0.59, Z: 0.98, W: 1.00},
+	StyleColorTab:            {X: 0.76, Y: 0.80, Z: 0.84, W: 0.93},
+	StyleColorTabHovered:     {X: 0.26, Y: 0.59, Z: 0.98, W: 0.80},
+	StyleColorTabActive:      {X: 0.60, Y: 0.73, Z: 0.88, W: 1.00},
+	StyleColorTextSelectedBg: {X: 0.26, Y: 0.59, Z: 0.98, W: 0.35},
+}
+
+// StyleDark returns a new setter with a dark theme (the same colors as giu's default style).
+func StyleDark() *StyleSetter {
+	return newThemeStyle(styleDarkColors, styleDarkWindowRounding, styleDarkFrameRounding)
+}
+
+// StyleLight returns a new setter with a light theme.
+func StyleLight() *StyleSetter {
+	return newThemeStyle(styleLightColors, styleLightWindowRounding, styleLightFrameRounding)
+}
+
+func newThemeStyle(colors map[StyleColorID]vec4Color, windowRounding, frameRounding float32) *StyleSetter {
+	ss := Style()
+
+	for id, col := range colors {
+		ss.SetColorVec4(id, col.X, col.Y, col.Z, col.W)
+	}
+
+	return ss.
+		SetStyleFloat(StyleVarWindowRounding, windowRounding).
+		SetStyleFloat(StyleVarFrameRounding, frameRounding).
+		SetStyleFloat(StyleVarGrabRounding, frameRounding)
+}
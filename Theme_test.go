@@ -0,0 +1,167 @@
+package giu
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseHexColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected color.RGBA
+		isValid  bool
+	}{
+		{"rgba", "#11223344", color.RGBA{0x11, 0x22, 0x33, 0x44}, true},
+		{"rgb", "#112233", color.RGBA{0x11, 0x22, 0x33, 0xff}, true},
+		{"no hash", "aabbccdd", color.RGBA{0xaa, 0xbb, 0xcc, 0xdd}, true},
+		{"too short", "#123", color.RGBA{}, false},
+		{"not hex", "#gg2233", color.RGBA{}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			col, err := parseHexColor(tc.input)
+			if !tc.isValid {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, col)
+		})
+	}
+}
+
+func Test_loadTheme(t *testing.T) {
+	ss, err := loadTheme(strings.NewReader(`{
+		"colors": {"WindowBg": "#102030ff"},
+		"styles": {"FrameRounding": 4, "FramePadding": {"x": 8, "y": 2}}
+	}`))
+
+	assert.NoError(t, err)
+	assert.Equal(t, color.RGBA{0x10, 0x20, 0x30, 0xff}, ss.colors[StyleColorWindowBg])
+	assert.Equal(t, float32(4), ss.styles[StyleVarFrameRounding])
+	assert.Equal(t, imgui.Vec2{X: 8, Y: 2}, ss.styles[StyleVarFramePadding])
+
+	invalid := []struct {
+		name  string
+		input string
+	}{
+		{"unknown field", `{"fonts": {}}`},
+		{"unknown color", `{"colors": {"Foo": "#ffffff"}}`},
+		{"invalid color", `{"colors": {"Text": "white"}}`},
+		{"unknown style", `{"styles": {"Foo": 1}}`},
+		{"float as vec2", `{"styles": {"FramePadding": 1}}`},
+		{"vec2 as float", `{"styles": {"FrameRounding": {"x": 1, "y": 1}}}`},
+		{"invalid json", `{"colors": `},
+	}
+
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := loadTheme(strings.NewReader(tc.input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_StyleSetter_JSON(t *testing.T) {
+	ss := Style().
+		SetColor(StyleColorWindowBg, color.RGBA{0x10, 0x20, 0x30, 0x80}).
+		SetColor(StyleColorText, color.RGBA{0xff, 0xff, 0xff, 0xff}).
+		SetStyle(StyleVarFramePadding, 8, 2).
+		SetStyleFloat(StyleVarFrameRounding, 4).
+		SetStyleFloat(StyleVarItemSpacing, 3)
+
+	var buf strings.Builder
+	assert.NoError(t, ss.ToJSON(&buf))
+	assert.Contains(t, buf.String(), `"WindowBg": "#10203080"`)
+
+	restored, err := Style().FromJSON(strings.NewReader(buf.String()))
+	assert.NoError(t, err)
+	assert.Equal(t, ss.colors, restored.colors)
+	assert.Equal(t, map[StyleVarID]interface{}{
+		StyleVarFramePadding:  imgui.Vec2{X: 8, Y: 2},
+		StyleVarFrameRounding: float32(4),
+		StyleVarItemSpacing:   imgui.Vec2{X: 3, Y: 3},
+	}, restored.styles)
+
+	existing := Style().SetStyleFloat(StyleVarWindowRounding, 2)
+	_, err = existing.FromJSON(strings.NewReader(`{"colors": {"Foo": "#ffffff"}}`))
+	assert.Error(t, err)
+	assert.Equal(t, map[StyleVarID]interface{}{StyleVarWindowRounding: float32(2)}, existing.styles, "setter modified on error")
+}
+
+func Test_DiffThemes(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	green := color.RGBA{G: 255, A: 255}
+
+	a := map[StyleColorID]color.Color{
+		StyleColorText:     red,
+		StyleColorWindowBg: red,
+		StyleColorButton:   red,
+	}
+	b := map[StyleColorID]color.Color{
+		StyleColorText:     color.NRGBA{R: 255, A: 255},
+		StyleColorWindowBg: green,
+		StyleColorBorder:   green,
+	}
+
+	assert.Equal(t, []ColorDiff{
+		{ID: StyleColorWindowBg, A: red, B: green},
+		{ID: StyleColorBorder, B: green},
+		{ID: StyleColorButton, A: red},
+	}, DiffThemes(a, b))
+	assert.Empty(t, DiffThemes(a, a))
+}
+
+func Test_MergeThemes(t *testing.T) {
+	red := color.RGBA{R: 255, A: 255}
+	green := color.RGBA{G: 255, A: 255}
+
+	base := map[StyleColorID]color.Color{
+		StyleColorText:     red,
+		StyleColorWindowBg: red,
+	}
+	override := map[StyleColorID]color.Color{
+		StyleColorWindowBg: green,
+		StyleColorButton:   green,
+	}
+
+	assert.Equal(t, map[StyleColorID]color.Color{
+		StyleColorText:     red,
+		StyleColorWindowBg: green,
+		StyleColorButton:   green,
+	}, MergeThemes(base, override))
+	assert.Equal(t, red, base[StyleColorWindowBg], "base should not be modified")
+}
+
+func Test_StyleDarkLight(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme func() *StyleSetter
+	}{
+		{"dark", StyleDark},
+		{"light", StyleLight},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ss := tc.theme()
+			assert.NotEmpty(t, ss.colors)
+			assert.Contains(t, ss.colors, StyleColorWindowBg)
+			assert.Contains(t, ss.styles, StyleVarFrameRounding)
+		})
+	}
+
+	dark, light := StyleDark(), StyleLight()
+	for _, id := range []StyleColorID{StyleColorWindowBg, StyleColorFrameBg, StyleColorButton, StyleColorText} {
+		assert.NotEqual(t, dark.colors[id], light.colors[id], "themes should differ in %s", styleColorNames[id])
+	}
+
+	assert.NotEqual(t, dark.styles[StyleVarFrameRounding], light.styles[StyleVarFrameRounding])
+}
@@ -2,13 +2,16 @@ package giu
 
 import (
 	"fmt"
+	"hash/crc32"
 	"image"
 	"image/color"
 	"image/draw"
 	"image/png"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/AllenDang/imgui-go"
 )
@@ -69,13 +72,101 @@ func ToVec2(pt image.Point) imgui.Vec2 {
 }
 
 // Vec4ToRGBA converts imgui's Vec4 to golang rgba color.
+// Channels are rounded, so that it reverts ToVec4Color.
 func Vec4ToRGBA(vec4 imgui.Vec4) color.RGBA {
+	channel := func(v float32) uint8 {
+		return uint8(math.Round(float64(v) * 255))
+	}
+
 	return color.RGBA{
-		R: uint8(vec4.X * 255),
-		G: uint8(vec4.Y * 255),
-		B: uint8(vec4.Z * 255),
-		A: uint8(vec4.W * 255),
+		R: channel(vec4.X),
+		G: channel(vec4.Y),
+		B: channel(vec4.Z),
+		A: channel(vec4.W),
+	}
+}
+
+// Vec4ToColor converts imgui's Vec4 to golang color.Color.
+func Vec4ToColor(vec4 imgui.Vec4) color.Color {
+	return Vec4ToRGBA(vec4)
+}
+
+// RelativeLuminance returns relative luminance (in range 0-1) of the color
+// as defined by WCAG 2.0.
+func RelativeLuminance(col color.Color) float64 {
+	const mask = 0xffff
+
+	linear := func(c uint32) float64 {
+		v := float64(c) / mask
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+
+		return math.Pow((v+0.055)/1.055, 2.4)
 	}
+
+	r, g, b, _ := col.RGBA()
+
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b)
+}
+
+// ContrastRatio returns contrast ratio (in range 1-21) of two colors
+// as defined by WCAG 2.0 (e.g. 4.5 is a minimum recommended for a normal text).
+func ContrastRatio(a, b color.Color) float64 {
+	la, lb := RelativeLuminance(a), RelativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// ensureContrast moves fg towards black or white (whichever gives better contrast
+// with all the backgrounds) until contrast ratio of fg and each of backgrounds
+// reaches threshold. If the threshold can't be reached for all of them
+// (e.g. for both a dark and a light background), the color with the best
+// contrast with the worst matching background is returned.
+func ensureContrast(fg color.Color, backgrounds []color.Color, threshold float64) color.Color {
+	minContrast := func(c color.Color) float64 {
+		result := math.Inf(1)
+		for _, bg := range backgrounds {
+			result = math.Min(result, ContrastRatio(c, bg))
+		}
+
+		return result
+	}
+
+	if minContrast(fg) >= threshold {
+		return fg
+	}
+
+	target := color.Color(color.White)
+	if minContrast(color.Black) > minContrast(color.White) {
+		target = color.Black
+	}
+
+	const steps = 10
+
+	from, to := ToVec4Color(fg), ToVec4Color(target)
+
+	result, best := fg, minContrast(fg)
+	for step := 1; step <= steps; step++ {
+		// keep fg's alpha
+		v := lerpVec4(from, to, float32(step)/steps)
+		v.W = from.W
+		c := Vec4ToRGBA(v)
+
+		contrast := minContrast(c)
+		if contrast > best {
+			result, best = c, contrast
+		}
+
+		if contrast >= threshold {
+			break
+		}
+	}
+
+	return result
 }
 
 // Update updates giu app
@@ -112,6 +203,69 @@ func SetCursorPos(pos image.Point) {
 	imgui.SetCursorPos(imgui.Vec2{X: float32(pos.X), Y: float32(pos.Y)})
 }
 
+// WindowToScreen converts a position inside of current window
+// (e.g. returned by GetCursorPos) into a position on the screen
+// (e.g. used by Canvas).
+func WindowToScreen(pos image.Point) image.Point {
+	return pos.Add(windowToScreenOffset())
+}
+
+// ScreenToWindow converts a position on the screen into a position
+// inside of current window (see WindowToScreen).
+func ScreenToWindow(pos image.Point) image.Point {
+	return pos.Sub(windowToScreenOffset())
+}
+
+// windowToScreenOffset returns current window's position on the screen
+// considering window's scroll.
+func windowToScreenOffset() image.Point {
+	windowPos := imgui.WindowPos()
+	return image.Pt(
+		int(windowPos.X-imgui.ScrollX()),
+		int(windowPos.Y-imgui.ScrollY()),
+	)
+}
+
+// ScrollToItem scrolls current window so that the most recently built item
+// is visible (centered). If the item is already visible, it does nothing.
+func ScrollToItem() {
+	itemMin, itemMax := imgui.GetItemRectMin(), imgui.GetItemRectMax()
+	windowPos, windowSize := imgui.WindowPos(), imgui.WindowSize()
+
+	// the content starts below the title bar (and menu bar) of the window
+	contentTop := windowPos.Y + imgui.CursorStartPos().Y + imgui.ScrollY() - imgui.CurrentStyle().WindowPadding().Y
+	if itemMin.Y >= contentTop && itemMax.Y <= windowPos.Y+windowSize.Y {
+		return
+	}
+
+	imgui.SetScrollHereY(0.5)
+}
+
+// HashID calculates imgui's ID of id in the ID scope given by seed (the same
+// as ImGui::GetID, when seed is the top of the ID stack).
+// It is CRC32 of id (seeded by seed); if id contains "###", only the part
+// starting at (last) "###" is hashed, so labels like "Save###save" keep their ID.
+func HashID(seed uint32, id string) uint32 {
+	if idx := strings.LastIndex(id, "###"); idx >= 0 {
+		id = id[idx:]
+	}
+
+	return crc32.Update(seed, crc32.IEEETable, []byte(id))
+}
+
+// GetWindowItemID returns imgui's ID of an item (e.g. label "Save##btn")
+// built in a top-level window of the title, nested in ID scopes given by path
+// (e.g. by imgui.PushID or RangeBuilder), e.g. GetWindowItemID("Main", "list", "Save##btn").
+// It is useful for tests comparing IDs of imgui items.
+func GetWindowItemID(windowTitle string, path ...string) uint32 {
+	id := HashID(0, windowTitle)
+	for _, p := range path {
+		id = HashID(id, p)
+	}
+
+	return id
+}
+
 // GetMousePos returns mouse position.
 func GetMousePos() image.Point {
 	pos := imgui.MousePos()
@@ -134,6 +288,35 @@ func CalcTextSizeV(text string, hideAfterDoubleHash bool, wrapWidth float32) (w,
 	return size.X, size.Y
 }
 
+// calcTextWidth returns width of the text displayed by imgui.Text (which doesn't hide "##").
+// imgui-go passes the string's NUL terminator to imgui, which measures it as a glyph,
+// unless it stops at "##" (or at the NUL) itself. Text containing "##" is measured
+// with a new line appended, which moves the terminator to a line of its own.
+func calcTextWidth(text string, wrapWidth float32) float32 {
+	if !strings.Contains(text, "##") {
+		w, _ := CalcTextSizeV(text, true, wrapWidth)
+		return w
+	}
+
+	w, _ := CalcTextSizeV(text+"\n", false, wrapWidth)
+
+	return w
+}
+
+// CalcTextSizeWithFont calculates size of the text displayed with the given font
+// (instead of the current one). If the font isn't registered (e.g. font atlas
+// hasn't been rebuilt yet), it returns zero size.
+func CalcTextSizeWithFont(text string, font *FontInfo) (width, height float32) {
+	if !PushFont(font) {
+		return 0, 0
+	}
+
+	// pushed font is used by CalcTextSize only; nothing is rendered here
+	defer PopFont()
+
+	return CalcTextSize(text)
+}
+
 // SetNextWindowSize sets size of the next window.
 func SetNextWindowSize(width, height float32) {
 	imgui.SetNextWindowSize(imgui.Vec2{X: width, Y: height})
@@ -155,6 +338,11 @@ func SetNextWindowPos(x, y float32) {
 	imgui.SetNextWindowPos(imgui.Vec2{X: x, Y: y})
 }
 
+// SetNextWindowFocus sets the next window focused (front-most).
+func SetNextWindowFocus() {
+	imgui.SetNextWindowFocus()
+}
+
 // SetNextWindowSizeV does similar to SetNextWIndowSize but allows to specify imgui.Condition.
 func SetNextWindowSizeV(width, height float32, condition ExecCondition) {
 	imgui.SetNextWindowSizeV(
@@ -166,6 +354,14 @@ func SetNextWindowSizeV(width, height float32, condition ExecCondition) {
 	)
 }
 
+// ShowDemoWindow shows imgui's demo window. If open is not nil,
+// the window has a close button. The demo window's Tools menu gives access
+// to imgui's metrics/debugger (useful for debugging layout and ID issues)
+// and the style editor.
+func ShowDemoWindow(open *bool) {
+	imgui.ShowDemoWindow(open)
+}
+
 // SetItemDefaultFocus set the item focused by default.
 func SetItemDefaultFocus() {
 	imgui.SetItemDefaultFocus()
@@ -7,8 +7,41 @@ import (
 
 	"github.com/AllenDang/imgui-go"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/colornames"
 )
 
+// newTestContext creates an imgui context for headless tests (destroyed
+// when the test finishes): ini file is disabled, display size, delta time
+// and the font atlas are set up, so that frames could be rendered.
+// Widgets' states, id scopes and widget index of the global Context
+// are reset when the test finishes as well.
+func newTestContext(tb testing.TB) imgui.IO {
+	tb.Helper()
+
+	ctx := imgui.CreateContext(nil)
+	tb.Cleanup(ctx.Destroy)
+	tb.Cleanup(resetTestContext)
+
+	io := imgui.CurrentIO()
+	io.SetIniFilename("")
+	io.SetDisplaySize(imgui.Vec2{X: 800, Y: 600})
+	io.SetDeltaTime(1.0 / 60)
+	_ = io.Fonts().TextureDataRGBA32()
+
+	return io
+}
+
+// resetTestContext clears the global Context modified by widgets built in tests.
+func resetTestContext() {
+	Context.state.Range(func(k, _ interface{}) bool {
+		Context.state.Delete(k)
+		return true
+	})
+
+	Context.idScope = nil
+	Context.widgetIndexCounter = 0
+}
+
 func Test_ToVec4(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -78,6 +111,11 @@ func Test_Vec4ToRGBA(t *testing.T) {
 			source:   imgui.Vec4{X: 0.61960787, Y: 0, Z: 0.6784314, W: 1},
 			expected: color.RGBA{R: 158, G: 0, B: 173, A: 255},
 		},
+		{
+			name:     "Converted by ToVec4Color",
+			source:   ToVec4Color(color.RGBA{R: 10, G: 20, B: 30, A: 128}),
+			expected: color.RGBA{R: 10, G: 20, B: 30, A: 128},
+		},
 	}
 
 	for _, test := range tests {
@@ -87,6 +125,31 @@ func Test_Vec4ToRGBA(t *testing.T) {
 	}
 }
 
+func Test_Vec4ToColor(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   imgui.Vec4
+		expected color.Color
+	}{
+		{
+			name:     "Green",
+			source:   imgui.Vec4{X: 0, Y: 1, Z: 0, W: 1},
+			expected: color.RGBA{R: 0, G: 255, B: 0, A: 255},
+		},
+		{
+			name:     "Transparent",
+			source:   imgui.Vec4{X: 0, Y: 0, Z: 0, W: 0},
+			expected: color.RGBA{R: 0, G: 0, B: 0, A: 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			assert.Equal(tt, test.expected, Vec4ToColor(test.source), "Unexpected result")
+		})
+	}
+}
+
 func Test_Assert(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -107,3 +170,113 @@ func Test_Assert(t *testing.T) {
 		})
 	}
 }
+
+func Test_ContrastRatio(t *testing.T) {
+	assert.InDelta(t, 21, ContrastRatio(color.Black, color.White), 0.01, "unexpected contrast of black and white")
+	assert.InDelta(t, 1, ContrastRatio(colornames.Red, colornames.Red), 0.01, "contrast of the same colors should be 1")
+	assert.Equal(t, ContrastRatio(color.Black, colornames.Gray), ContrastRatio(colornames.Gray, color.Black), "contrast should be symmetric")
+}
+
+func Test_ensureContrast(t *testing.T) {
+	tests := []struct {
+		name string
+		fg   color.Color
+		bg   color.Color
+	}{
+		{"dark on dark", color.RGBA{40, 40, 40, 255}, color.RGBA{20, 20, 20, 255}},
+		{"light on light", color.RGBA{220, 220, 220, 255}, color.RGBA{240, 240, 240, 255}},
+		{"gray on gray", colornames.Gray, colornames.Darkgray},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ensureContrast(tc.fg, []color.Color{tc.bg}, 4.5)
+			assert.GreaterOrEqual(t, ContrastRatio(result, tc.bg), 4.5, "contrast hasn't been corrected")
+		})
+	}
+
+	good := color.RGBA{255, 255, 255, 255}
+	assert.Equal(t, color.Color(good), ensureContrast(good, []color.Color{color.Black}, 4.5), "color with good contrast shouldn't change")
+
+	// the first background alone would move the color towards black,
+	// which can't give enough contrast with the second one
+	backgrounds := []color.Color{color.RGBA{118, 118, 118, 255}, color.RGBA{20, 20, 20, 255}}
+	result := ensureContrast(color.RGBA{100, 100, 100, 255}, backgrounds, 4.5)
+
+	for _, bg := range backgrounds {
+		assert.GreaterOrEqual(t, ContrastRatio(result, bg), 4.5, "contrast should be corrected for all the backgrounds")
+	}
+}
+
+func Test_GetWindowItemID(t *testing.T) {
+	// expected values are calculated by imgui's ImHashStr
+	tests := []struct {
+		name     string
+		title    string
+		path     []string
+		expected uint32
+	}{
+		{"window", "Main", nil, 521822810},
+		{"item", "Main", []string{"Button"}, 1536423169},
+		{"item with ##", "Main", []string{"Save##btn"}, 3160807367},
+		{"item with ###", "Main", []string{"Label###id"}, 155774933},
+		{"### ignores prefix", "Main", []string{"Other###id"}, 155774933},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetWindowItemID(tc.title, tc.path...))
+		})
+	}
+}
+
+func Test_ScrollToItem(t *testing.T) {
+	newTestContext(t)
+
+	var (
+		scroll           bool
+		scrollY, top     float32
+		itemMin, itemMax imgui.Vec2
+		windowBottom     float32
+	)
+
+	frame := func(itemY float32) {
+		imgui.NewFrame()
+		imgui.SetNextWindowPos(imgui.Vec2{X: 0, Y: 0})
+		imgui.SetNextWindowSize(imgui.Vec2{X: 200, Y: 200})
+		imgui.Begin("scroll to item")
+
+		top = imgui.WindowPos().Y + frameHeight()
+		windowBottom = imgui.WindowPos().Y + imgui.WindowHeight()
+
+		imgui.Dummy(imgui.Vec2{X: 10, Y: itemY})
+		imgui.Button("target")
+		itemMin, itemMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+
+		if scroll {
+			ScrollToItem()
+			scroll = false
+		}
+
+		imgui.Dummy(imgui.Vec2{X: 10, Y: 1000})
+		scrollY = imgui.ScrollY()
+
+		imgui.End()
+		imgui.Render()
+	}
+
+	frame(50)
+	frame(50)
+
+	scroll = true
+	frame(50)
+	frame(50)
+	assert.Equal(t, float32(0), scrollY, "visible item shouldn't be scrolled to")
+
+	scroll = true
+	frame(600)
+	frame(600)
+	assert.Greater(t, scrollY, float32(0), "hidden item should be scrolled to")
+	assert.GreaterOrEqual(t, itemMin.Y, top, "item should be below the title bar")
+	assert.LessOrEqual(t, itemMax.Y, windowBottom, "item should be in the window")
+}
@@ -3,8 +3,10 @@ package giu
 import (
 	"fmt"
 	"image/color"
+	"time"
 
 	"github.com/AllenDang/imgui-go"
+	"github.com/sahilm/fuzzy"
 )
 
 // GenAutoID automatically generates fidget's id.
@@ -37,7 +39,8 @@ func (l *RowWidget) Build() {
 			*PopupWidget, *TabItemWidget:
 			// noop
 		default:
-			if _, isLabel := w.(*LabelWidget); isLabel {
+			switch w.(type) {
+			case *LabelWidget, *HelpMarkerWidget:
 				AlignTextToFramePadding()
 			}
 
@@ -52,12 +55,59 @@ func (l *RowWidget) Build() {
 	})
 }
 
+var _ Widget = &SameLineWidget{}
+
+// SameLineWidget puts the next widget in the same line as the previous one.
+// Don't use if you don't have to (use RowWidget instead).
+type SameLineWidget struct {
+	offsetX float32
+	spacing float32
+}
+
 // SameLine wrapps imgui.SomeLine
 // Don't use if you don't have to (use RowWidget instead).
+// To put it into a layout, use SameLineItem.
 func SameLine() {
 	imgui.SameLine()
 }
 
+// SameLineItem creates a new SameLineWidget.
+func SameLineItem() *SameLineWidget {
+	return SameLineItemV(0, -1)
+}
+
+// SameLineItemV creates a new SameLineWidget with offsetX (from the start
+// of the window's content) and spacing (-1 means default item spacing).
+func SameLineItemV(offsetX, spacing float32) *SameLineWidget {
+	return &SameLineWidget{
+		offsetX: offsetX,
+		spacing: spacing,
+	}
+}
+
+// Build implements Widget interface.
+func (s *SameLineWidget) Build() {
+	imgui.SameLineV(s.offsetX, s.spacing)
+}
+
+var _ Widget = &NewLineWidget{}
+
+// NewLineWidget ends the current line, so the next widget is put in
+// a new line (e.g. after SameLineItem).
+type NewLineWidget struct{}
+
+// NewLine creates a new NewLineWidget.
+func NewLine() *NewLineWidget {
+	return &NewLineWidget{}
+}
+
+// Build implements Widget interface.
+func (n *NewLineWidget) Build() {
+	// imgui-go doesn't wrap imgui.NewLine; as imgui's NewLine, an item of a line height
+	// ends the current line or (if there's nothing on the line) adds an empty one
+	imgui.Dummy(imgui.Vec2{Y: imgui.FontSize()})
+}
+
 var _ Widget = &ChildWidget{}
 
 type ChildWidget struct {
@@ -109,7 +159,68 @@ func Child() *ChildWidget {
 	}
 }
 
-var _ Widget = &ComboCustomWidget{}
+var _ Widget = &StatusBarWidget{}
+
+// StatusBarWidget is a bar pinned to the bottom of the current window
+// (e.g. for status labels aligned by Align(AlignRight)).
+// NOTE: the bar is placed in the remaining space of the window, so if
+// the content above is higher than the window, the bar is placed after it.
+// To keep the bar visible then, put the content in a Child.
+type StatusBarWidget struct {
+	id     string
+	layout Layout
+}
+
+// StatusBar creates a new StatusBarWidget.
+func StatusBar() *StatusBarWidget {
+	return &StatusBarWidget{
+		id: GenAutoID("StatusBar"),
+	}
+}
+
+// ID allows to manually set widget's id.
+func (s *StatusBarWidget) ID(id string) *StatusBarWidget {
+	s.id = id
+	return s
+}
+
+// To sets bar's layout.
+func (s *StatusBarWidget) To(widgets ...Widget) *StatusBarWidget {
+	s.layout = widgets
+	return s
+}
+
+// Build implements Widget interface.
+func (s *StatusBarWidget) Build() {
+	const verticalPadding = 2
+
+	framePaddingX, framePaddingY := GetFramePadding()
+	height := imgui.TextLineHeight() + 2*framePaddingY + 2*verticalPadding
+
+	// move to the bottom (computed every frame, so the bar stays there on resize)
+	if _, availableH := GetAvailableRegion(); availableH > height {
+		imgui.SetCursorPos(imgui.Vec2{X: imgui.CursorPosX(), Y: imgui.CursorPosY() + availableH - height})
+	}
+
+	imgui.PushStyleColor(imgui.StyleColorChildBg, imgui.CurrentStyle().GetColor(imgui.StyleColorMenuBarBg))
+	PushWindowPadding(framePaddingX, verticalPadding)
+
+	// the child has no border, so the padding has to be enabled explicitly
+	flags := WindowFlagsNoScrollbar | WindowFlagsNoScrollWithMouse | WindowFlagsAlwaysUseWindowPadding
+	if imgui.BeginChildV(s.id, imgui.Vec2{X: 0, Y: height}, false, int(flags)) {
+		s.layout.Build()
+	}
+
+	imgui.EndChild()
+
+	PopStyle()
+	imgui.PopStyleColor()
+}
+
+var (
+	_ Widget     = &ComboCustomWidget{}
+	_ Measurable = &ComboCustomWidget{}
+)
 
 // ComboCustomWidget represents a combo with custom layout when opened.
 type ComboCustomWidget struct {
@@ -162,7 +273,37 @@ func (cc *ComboCustomWidget) Build() {
 	}
 }
 
-var _ Widget = &ComboWidget{}
+// calcComboWidth returns width of a combo (including its label)
+// without building it.
+func calcComboWidth(label string, width float32, flags ComboFlags) float32 {
+	var w float32
+
+	switch {
+	// only the square arrow button
+	case flags&ComboFlagsNoPreview != 0:
+		w = frameHeight()
+	case width > 0:
+		w = width
+	default:
+		w = imgui.CalcItemWidth()
+	}
+
+	if labelW, _ := CalcTextSizeV(tStr(label), true, -1); labelW > 0 {
+		w += imgui.CurrentStyle().ItemInnerSpacing().X + labelW
+	}
+
+	return w
+}
+
+// CalcWidth implements Measurable interface.
+func (cc *ComboCustomWidget) CalcWidth() float32 {
+	return calcComboWidth(cc.label, cc.width, cc.flags)
+}
+
+var (
+	_ Widget     = &ComboWidget{}
+	_ Measurable = &ComboWidget{}
+)
 
 // ComboWidget is a wrapper of ComboCustomWidget.
 // It creates a combo of selectables. (it is the most frequently used).
@@ -210,6 +351,11 @@ func (c *ComboWidget) Build() {
 	}
 }
 
+// CalcWidth implements Measurable interface.
+func (c *ComboWidget) CalcWidth() float32 {
+	return calcComboWidth(c.label, c.width, c.flags)
+}
+
 // Flags allows to set combo flags (see Flags.go).
 func (c *ComboWidget) Flags(flags ComboFlags) *ComboWidget {
 	c.flags = flags
@@ -228,6 +374,123 @@ func (c *ComboWidget) OnChange(onChange func()) *ComboWidget {
 	return c
 }
 
+var _ Disposable = &searchableComboState{}
+
+type searchableComboState struct {
+	filter string
+}
+
+// Dispose implements Disposable interface.
+func (s *searchableComboState) Dispose() {
+	// noop
+}
+
+var _ Widget = &SearchableComboWidget{}
+
+// SearchableComboWidget is a combo with a search box on top of the list.
+// Options are filtered using fuzzy matching.
+type SearchableComboWidget struct {
+	id       string
+	label    string
+	selected *string
+	options  []string
+	width    float32
+	onChange func()
+}
+
+// SearchableCombo creates a new SearchableComboWidget.
+func SearchableCombo(selected *string, options []string) *SearchableComboWidget {
+	return &SearchableComboWidget{
+		id:       GenAutoID("SearchableCombo"),
+		selected: selected,
+		options:  tStrSlice(options),
+	}
+}
+
+// ID allows to manually set widget's id.
+func (c *SearchableComboWidget) ID(id string) *SearchableComboWidget {
+	c.id = id
+	return c
+}
+
+// Label sets label displayed next to the combo.
+func (c *SearchableComboWidget) Label(label string) *SearchableComboWidget {
+	c.label = tStr(label)
+	return c
+}
+
+// Size sets combo's width.
+func (c *SearchableComboWidget) Size(width float32) *SearchableComboWidget {
+	c.width = width
+	return c
+}
+
+// OnChange sets callback when combo value gets changed.
+func (c *SearchableComboWidget) OnChange(onChange func()) *SearchableComboWidget {
+	c.onChange = onChange
+	return c
+}
+
+// Build implements Widget interface.
+func (c *SearchableComboWidget) Build() {
+	var state *searchableComboState
+	if s := Context.GetState(c.id); s == nil {
+		state = &searchableComboState{}
+		Context.SetState(c.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*searchableComboState)
+		Assert(isOk, "SearchableComboWidget", "Build", "unexpected state recovered")
+	}
+
+	if c.selected == nil {
+		return
+	}
+
+	if c.width > 0 {
+		imgui.PushItemWidth(c.width)
+		defer imgui.PopItemWidth()
+	}
+
+	if !imgui.BeginCombo(fmt.Sprintf("%s##%s", c.label, c.id), *c.selected) {
+		return
+	}
+
+	defer imgui.EndCombo()
+
+	if imgui.IsWindowAppearing() {
+		state.filter = ""
+	}
+
+	// keep focus in the search box
+	if !imgui.IsAnyItemActive() {
+		imgui.SetKeyboardFocusHere()
+	}
+
+	imgui.PushItemWidth(-1)
+	imgui.InputTextWithHint("##Filter", "Search...", tStrPtr(&state.filter), 0, nil)
+	imgui.PopItemWidth()
+
+	options := c.options
+	if state.filter != "" {
+		matches := fuzzy.Find(state.filter, c.options)
+		options = make([]string, len(matches))
+
+		for i, m := range matches {
+			options[i] = m.Str
+		}
+	}
+
+	for _, option := range options {
+		if imgui.SelectableV(option, option == *c.selected, 0, imgui.Vec2{}) {
+			*c.selected = option
+			if c.onChange != nil {
+				c.onChange()
+			}
+		}
+	}
+}
+
 var _ Widget = &ContextMenuWidget{}
 
 type ContextMenuWidget struct {
@@ -575,12 +838,38 @@ func (t *TabItemWidget) Layout(widgets ...Widget) *TabItemWidget {
 	return t
 }
 
+// To is an alias to Layout.
+func (t *TabItemWidget) To(widgets ...Widget) *TabItemWidget {
+	return t.Layout(widgets...)
+}
+
 // Build implements Widget interface.
 func (t *TabItemWidget) Build() {
-	if imgui.BeginTabItemV(t.label, t.open, int(t.flags)) {
+	t.build(t.flags)
+}
+
+// build builds tab item with specified flags and returns true
+// if the tab is currently selected.
+func (t *TabItemWidget) build(flags TabItemFlags) bool {
+	if imgui.BeginTabItemV(t.label, t.open, int(flags)) {
 		t.layout.Build()
 		imgui.EndTabItem()
+
+		return true
 	}
+
+	return false
+}
+
+var _ Disposable = &tabBarState{}
+
+type tabBarState struct {
+	selected int32
+}
+
+// Dispose implements Disposable interface.
+func (s *tabBarState) Dispose() {
+	// noop
 }
 
 var _ Widget = &TabBarWidget{}
@@ -589,6 +878,9 @@ type TabBarWidget struct {
 	id       string
 	flags    TabBarFlags
 	tabItems []*TabItemWidget
+	selected *int32
+	onSelect func(index int32)
+	onClose  func(index int32)
 }
 
 func TabBar() *TabBarWidget {
@@ -603,6 +895,17 @@ func (t *TabBarWidget) Flags(flags TabBarFlags) *TabBarWidget {
 	return t
 }
 
+// Reorderable allows user to re-order tabs by dragging them.
+func (t *TabBarWidget) Reorderable(reorderable bool) *TabBarWidget {
+	if reorderable {
+		t.flags |= TabBarFlagsReorderable
+	} else {
+		t.flags &^= TabBarFlagsReorderable
+	}
+
+	return t
+}
+
 func (t *TabBarWidget) ID(id string) *TabBarWidget {
 	t.id = id
 	return t
@@ -613,38 +916,177 @@ func (t *TabBarWidget) TabItems(items ...*TabItemWidget) *TabBarWidget {
 	return t
 }
 
+// To is an alias to TabItems.
+func (t *TabBarWidget) To(items ...*TabItemWidget) *TabBarWidget {
+	return t.TabItems(items...)
+}
+
+// Selected binds index of the selected tab.
+// Changing the value of `selected` selects the tab programmatically.
+func (t *TabBarWidget) Selected(selected *int32) *TabBarWidget {
+	t.selected = selected
+	return t
+}
+
+// OnSelect sets callback called when user selects a tab.
+func (t *TabBarWidget) OnSelect(onSelect func(index int32)) *TabBarWidget {
+	t.onSelect = onSelect
+	return t
+}
+
+// OnClose sets callback called when user closes a tab
+// NOTE: only tabs with IsOpen set can be closed.
+func (t *TabBarWidget) OnClose(onClose func(index int32)) *TabBarWidget {
+	t.onClose = onClose
+	return t
+}
+
 // Build implements Widget interface.
 func (t *TabBarWidget) Build() {
+	state := t.getState()
+
+	// selected tab was changed from outside
+	setSelected := int32(-1)
+	if t.selected != nil && *t.selected != state.selected {
+		setSelected = *t.selected
+		state.selected = setSelected
+	}
+
 	if imgui.BeginTabBarV(t.id, int(t.flags)) {
-		for _, ti := range t.tabItems {
-			ti.Build()
+		for i, ti := range t.tabItems {
+			index := int32(i)
+
+			flags := ti.flags
+			if index == setSelected {
+				flags |= TabItemFlagsSetSelected
+			}
+
+			wasOpen := ti.open != nil && *ti.open
+
+			// when selection is set programmatically, imgui applies it in the next frame
+			if ti.build(flags) && setSelected < 0 && state.selected != index {
+				state.selected = index
+				if t.selected != nil {
+					*t.selected = index
+				}
+
+				if t.onSelect != nil {
+					t.onSelect(index)
+				}
+			}
+
+			if wasOpen && !*ti.open && t.onClose != nil {
+				t.onClose(index)
+			}
 		}
+
 		imgui.EndTabBar()
 	}
 }
 
+func (t *TabBarWidget) getState() (state *tabBarState) {
+	if s := Context.GetState(t.id); s == nil {
+		state = &tabBarState{selected: -1}
+		Context.SetState(t.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*tabBarState)
+		Assert(isOk, "TabBarWidget", "getState", "unexpected state recovered")
+	}
+
+	return state
+}
+
+var _ Disposable = &tooltipState{}
+
+type tooltipState struct {
+	hoverStart time.Time
+}
+
+// Dispose implements Disposable interface.
+func (s *tooltipState) Dispose() {
+	// noop
+}
+
 var _ Widget = &TooltipWidget{}
 
 type TooltipWidget struct {
-	tip    string
-	layout Layout
+	id       string
+	tip      string
+	layout   Layout
+	maxWidth float32
+	delay    time.Duration
 }
 
 // Build implements Widget interface.
 func (t *TooltipWidget) Build() {
-	if imgui.IsItemHovered() {
-		if t.layout != nil {
-			imgui.BeginTooltip()
-			t.layout.Build()
-			imgui.EndTooltip()
-		} else {
-			imgui.SetTooltip(t.tip)
-		}
+	if !t.shouldShow() {
+		return
+	}
+
+	if t.layout == nil && t.maxWidth <= 0 {
+		imgui.SetTooltip(t.tip)
+		return
+	}
+
+	imgui.BeginTooltip()
+
+	// the wrap pos is stored in the tooltip window, so it has to be popped before EndTooltip
+	if t.maxWidth > 0 {
+		imgui.PushTextWrapPosV(t.maxWidth)
+	}
+
+	if t.layout != nil {
+		t.layout.Build()
+	} else {
+		imgui.Text(t.tip)
 	}
+
+	if t.maxWidth > 0 {
+		imgui.PopTextWrapPos()
+	}
+
+	imgui.EndTooltip()
+}
+
+// shouldShow returns true if item is hovered (for at least t.delay).
+func (t *TooltipWidget) shouldShow() bool {
+	isHovered := imgui.IsItemHovered()
+	if t.delay <= 0 {
+		return isHovered
+	}
+
+	var state *tooltipState
+	if s := Context.GetState(t.id); s == nil {
+		state = &tooltipState{}
+		Context.SetState(t.id, state)
+	} else {
+		var isOk bool
+		state, isOk = s.(*tooltipState)
+		Assert(isOk, "TooltipWidget", "Build", "unexpected state recovered")
+	}
+
+	if !isHovered {
+		state.hoverStart = time.Time{}
+		return false
+	}
+
+	if state.hoverStart.IsZero() {
+		state.hoverStart = time.Now()
+	}
+
+	if time.Since(state.hoverStart) < t.delay {
+		// imgui doesn't render new frames if there is no user input
+		Update()
+		return false
+	}
+
+	return true
 }
 
 func Tooltip(tip string) *TooltipWidget {
 	return &TooltipWidget{
+		id:     GenAutoID("Tooltip"),
 		tip:    tStr(tip),
 		layout: nil,
 	}
@@ -659,6 +1101,77 @@ func (t *TooltipWidget) Layout(widgets ...Widget) *TooltipWidget {
 	return t
 }
 
+// MaxWidth sets maximal width of the tooltip. Longer text is wrapped.
+func (t *TooltipWidget) MaxWidth(width float32) *TooltipWidget {
+	t.maxWidth = width
+	return t
+}
+
+// Delay sets how long the item needs to be hovered before the tooltip is shown.
+func (t *TooltipWidget) Delay(delay time.Duration) *TooltipWidget {
+	t.delay = delay
+	return t
+}
+
+var _ Widget = &HelpMarkerWidget{}
+
+// HelpMarkerWidget shows a dimmed "(?)" mark, which shows a (wrapped)
+// tooltip when hovered. It is intended to be put after a widget in Row.
+type HelpMarkerWidget struct {
+	text string
+	icon string
+	font *FontInfo
+}
+
+// HelpMarker creates a new HelpMarkerWidget.
+func HelpMarker(text string) *HelpMarkerWidget {
+	return &HelpMarkerWidget{
+		text: tStr(text),
+		icon: "(?)",
+		font: nil,
+	}
+}
+
+// HelpMarkerf is a formatting version of HelpMarker.
+func HelpMarkerf(format string, args ...interface{}) *HelpMarkerWidget {
+	return HelpMarker(fmt.Sprintf(format, args...))
+}
+
+// Icon sets a custom glyph displayed instead of "(?)".
+func (h *HelpMarkerWidget) Icon(icon string) *HelpMarkerWidget {
+	h.icon = tStr(icon)
+	return h
+}
+
+// Font sets font used to display icon (e.g. icon font).
+func (h *HelpMarkerWidget) Font(font *FontInfo) *HelpMarkerWidget {
+	h.font = font
+	return h
+}
+
+// Build implements Widget interface.
+func (h *HelpMarkerWidget) Build() {
+	imgui.PushStyleColor(imgui.StyleColorText, imgui.CurrentStyle().GetColor(imgui.StyleColorTextDisabled))
+
+	isFontPushed := PushFont(h.font)
+
+	imgui.Text(h.icon)
+
+	if isFontPushed {
+		PopFont()
+	}
+
+	imgui.PopStyleColor()
+
+	if imgui.IsItemHovered() {
+		imgui.BeginTooltip()
+		imgui.PushTextWrapPosV(imgui.FontSize() * 35)
+		imgui.Text(h.text)
+		imgui.PopTextWrapPos()
+		imgui.EndTooltip()
+	}
+}
+
 var _ Widget = &SpacingWidget{}
 
 type SpacingWidget struct{}
@@ -672,6 +1185,25 @@ func Spacing() *SpacingWidget {
 	return &SpacingWidget{}
 }
 
+var _ Widget = &SpacerWidget{}
+
+// SpacerWidget adds a vertical gap of n spacings (see Spacing).
+type SpacerWidget struct {
+	n int
+}
+
+// Spacer creates a new SpacerWidget. If n <= 0, nothing is built.
+func Spacer(n int) *SpacerWidget {
+	return &SpacerWidget{n: n}
+}
+
+// Build implements Widget interface.
+func (s *SpacerWidget) Build() {
+	for i := 0; i < s.n; i++ {
+		imgui.Spacing()
+	}
+}
+
 var _ Widget = &ColorEditWidget{}
 
 type ColorEditWidget struct {
@@ -705,6 +1237,24 @@ func (ce *ColorEditWidget) Size(width float32) *ColorEditWidget {
 	return ce
 }
 
+// Alpha sets whether alpha component could be edited.
+func (ce *ColorEditWidget) Alpha(enabled bool) *ColorEditWidget {
+	ce.flags = toggleColorEditFlag(ce.flags, ColorEditFlagsNoAlpha, !enabled)
+	return ce
+}
+
+// NoInputs hides inputs (only the small preview colored square is shown).
+func (ce *ColorEditWidget) NoInputs(noInputs bool) *ColorEditWidget {
+	ce.flags = toggleColorEditFlag(ce.flags, ColorEditFlagsNoInputs, noInputs)
+	return ce
+}
+
+// NoLabel hides inline label.
+func (ce *ColorEditWidget) NoLabel(noLabel bool) *ColorEditWidget {
+	ce.flags = toggleColorEditFlag(ce.flags, ColorEditFlagsNoLabel, noLabel)
+	return ce
+}
+
 // Build implements Widget interface.
 func (ce *ColorEditWidget) Build() {
 	c := ToVec4Color(*ce.color)
@@ -739,3 +1289,152 @@ func (ce *ColorEditWidget) Build() {
 		imgui.PopItemWidth()
 	}
 }
+
+func toggleColorEditFlag(flags, flag ColorEditFlags, enabled bool) ColorEditFlags {
+	if enabled {
+		return flags | flag
+	}
+
+	return flags &^ flag
+}
+
+var _ Widget = &ColorPickerWidget{}
+
+// ColorPickerWidget represents imgui's color picker
+// bound to color.Color.
+type ColorPickerWidget struct {
+	label    string
+	color    *color.Color
+	flags    ColorEditFlags
+	width    float32
+	onChange func()
+}
+
+// ColorPicker creates a new ColorPickerWidget.
+func ColorPicker(label string, c *color.Color) *ColorPickerWidget {
+	return &ColorPickerWidget{
+		label: GenAutoID(label),
+		color: c,
+		flags: ColorEditFlagsNone,
+	}
+}
+
+// OnChange sets callback called when color gets changed.
+func (cp *ColorPickerWidget) OnChange(cb func()) *ColorPickerWidget {
+	cp.onChange = cb
+	return cp
+}
+
+// Flags sets color edit flags (see Flags.go).
+func (cp *ColorPickerWidget) Flags(f ColorEditFlags) *ColorPickerWidget {
+	cp.flags = f
+	return cp
+}
+
+// Size sets picker's width.
+func (cp *ColorPickerWidget) Size(width float32) *ColorPickerWidget {
+	cp.width = width
+	return cp
+}
+
+// Alpha sets whether alpha component could be edited.
+// If enabled, an alpha bar is shown.
+func (cp *ColorPickerWidget) Alpha(enabled bool) *ColorPickerWidget {
+	cp.flags = toggleColorEditFlag(cp.flags, ColorEditFlagsNoAlpha, !enabled)
+	cp.flags = toggleColorEditFlag(cp.flags, ColorEditFlagsAlphaBar, enabled)
+
+	return cp
+}
+
+// NoInputs hides inputs below the picker.
+func (cp *ColorPickerWidget) NoInputs(noInputs bool) *ColorPickerWidget {
+	cp.flags = toggleColorEditFlag(cp.flags, ColorEditFlagsNoInputs, noInputs)
+	return cp
+}
+
+// NoLabel hides inline label.
+func (cp *ColorPickerWidget) NoLabel(noLabel bool) *ColorPickerWidget {
+	cp.flags = toggleColorEditFlag(cp.flags, ColorEditFlagsNoLabel, noLabel)
+	return cp
+}
+
+// Build implements Widget interface.
+func (cp *ColorPickerWidget) Build() {
+	if cp.color == nil {
+		return
+	}
+
+	var c imgui.Vec4
+	if *cp.color != nil {
+		c = ToVec4Color(*cp.color)
+	}
+
+	col := [4]float32{
+		c.X,
+		c.Y,
+		c.Z,
+		c.W,
+	}
+
+	if cp.width > 0 {
+		imgui.PushItemWidth(cp.width)
+		defer imgui.PopItemWidth()
+	}
+
+	if imgui.ColorPicker4V(
+		tStr(cp.label),
+		&col,
+		int(cp.flags),
+	) {
+		*cp.color = Vec4ToColor(imgui.Vec4{
+			X: col[0],
+			Y: col[1],
+			Z: col[2],
+			W: col[3],
+		})
+
+		if cp.onChange != nil {
+			cp.onChange()
+		}
+	}
+}
+
+var _ Widget = &FormWidthWidget{}
+
+// FormWidthWidget gives all fields of a form the same width, which is
+// a fraction of the available width (so the fields line up and
+// resize together with the window).
+// NOTE: fields with width set explicitly (e.g. by Size) keep their width.
+type FormWidthWidget struct {
+	fraction float32
+	layout   Layout
+}
+
+// FormWidth creates a new FormWidthWidget (fraction should be in range 0-1).
+func FormWidth(fraction float32) *FormWidthWidget {
+	return &FormWidthWidget{
+		fraction: fraction,
+	}
+}
+
+// To sets the form's fields.
+func (f *FormWidthWidget) To(fields ...Widget) *FormWidthWidget {
+	f.layout = fields
+	return f
+}
+
+// Build implements Widget interface.
+func (f *FormWidthWidget) Build() {
+	availableW, _ := GetAvailableRegion()
+	width := f.fraction * availableW
+
+	for _, field := range f.layout {
+		if field == nil {
+			continue
+		}
+
+		PushItemWidth(width)
+		field.Build()
+		PopItemWidth()
+	}
+}
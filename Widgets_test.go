@@ -0,0 +1,121 @@
+package giu
+
+import (
+	"testing"
+
+	"github.com/AllenDang/imgui-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Spacer(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("spacer")
+
+	// advance returns how far the cursor moves while building w
+	advance := func(w Widget) float32 {
+		start := imgui.CursorPosY()
+		w.Build()
+
+		return imgui.CursorPosY() - start
+	}
+
+	single := advance(Spacing())
+	assert.Greater(t, single, float32(0), "spacing didn't move the cursor")
+
+	tests := []struct {
+		n        int
+		expected float32
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, single},
+		{3, 3 * single},
+	}
+
+	for _, tc := range tests {
+		assert.InDelta(t, tc.expected, advance(Spacer(tc.n)), 0.01, "unexpected advance of Spacer(%d)", tc.n)
+	}
+
+	imgui.End()
+	imgui.Render()
+}
+
+func Test_NewLine(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("new line")
+
+	_, spacingY := GetItemSpacing()
+	lineStartX := imgui.CursorPosX()
+
+	// without SameLineItem, NewLine adds an empty line
+	start := imgui.CursorPosY()
+	NewLine().Build()
+	assert.InDelta(t, imgui.FontSize()+spacingY, imgui.CursorPosY()-start, 0.01, "NewLine should add an empty line")
+
+	// after SameLineItem, NewLine just ends the line
+	start = imgui.CursorPosY()
+	Label("label").Build()
+	labelAdvance := imgui.CursorPosY() - start
+
+	start = imgui.CursorPosY()
+	Layout{Label("label"), SameLineItem(), NewLine()}.Build()
+	assert.InDelta(t, labelAdvance, imgui.CursorPosY()-start, 0.01, "NewLine after SameLine shouldn't add an empty line")
+	assert.Equal(t, lineStartX, imgui.CursorPosX(), "the next widget should start a new line")
+
+	imgui.End()
+	imgui.Render()
+}
+
+func Test_TooltipWidget_MaxWidth(t *testing.T) {
+	io := newTestContext(t)
+
+	var itemMin, itemMax imgui.Vec2
+
+	// frame returns number of rendered windows
+	frame := func() int {
+		imgui.NewFrame()
+		imgui.Begin("tooltip max width")
+		Button("hover me").Build()
+		itemMin, itemMax = imgui.GetItemRectMin(), imgui.GetItemRectMax()
+		Tooltip("a long tooltip which should be wrapped").MaxWidth(50).Build()
+		imgui.End()
+		imgui.Render()
+
+		return len(imgui.RenderedDrawData().CommandLists())
+	}
+
+	// the new window is hidden in its first frame
+	frame()
+
+	withoutTooltip := frame()
+
+	// hover the button (a new tooltip window is hidden in its first frame)
+	io.SetMousePosition(imgui.Vec2{X: (itemMin.X + itemMax.X) / 2, Y: (itemMin.Y + itemMax.Y) / 2})
+	frame()
+	frame()
+	assert.Equal(t, withoutTooltip+1, frame(), "tooltip wasn't shown on hover")
+}
+
+func Test_StatusBarWidget_Padding(t *testing.T) {
+	newTestContext(t)
+
+	imgui.NewFrame()
+	imgui.Begin("status bar")
+
+	var offset imgui.Vec2
+
+	StatusBar().To(Custom(func() {
+		pos, windowPos := imgui.CursorScreenPos(), imgui.WindowPos()
+		offset = imgui.Vec2{X: pos.X - windowPos.X, Y: pos.Y - windowPos.Y}
+	})).Build()
+
+	imgui.End()
+	imgui.Render()
+
+	framePaddingX, _ := GetFramePadding()
+	assert.Equal(t, imgui.Vec2{X: framePaddingX, Y: 2}, offset, "status bar's content should be padded")
+}
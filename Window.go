@@ -39,6 +39,8 @@ var _ Disposable = &windowState{}
 
 type windowState struct {
 	hasFocus bool
+	// set by SetWindowFocus
+	focusRequested bool
 	currentPosition,
 	currentSize imgui.Vec2
 }
@@ -114,9 +116,10 @@ func (w *WindowWidget) Layout(widgets ...Widget) {
 		imgui.SetNextWindowSizeV(imgui.Vec2{X: w.width, Y: w.height}, imgui.ConditionFirstUseEver)
 	}
 
-	if w.bringToFront {
+	if w.bringToFront || ws.focusRequested {
 		imgui.SetNextWindowFocus()
 		w.bringToFront = false
+		ws.focusRequested = false
 	}
 
 	widgets = append(widgets,
@@ -159,6 +162,13 @@ func (w *WindowWidget) BringToFront() {
 	w.bringToFront = true
 }
 
+// SetWindowFocus focuses the window with the given title
+// when it is built next time. Unlike (*WindowWidget).BringToFront,
+// it could be called from anywhere (e.g. from other window's callback).
+func SetWindowFocus(title string) {
+	Window(title).getState().focusRequested = true
+}
+
 // HasFocus returns true if window is focused.
 func (w *WindowWidget) HasFocus() bool {
 	return w.getState().hasFocus
@@ -2,9 +2,15 @@ package main
 
 import "github.com/AllenDang/giu"
 
-var text string
+var (
+	text     string
+	selected int32
+	items    = []string{"item 1", "item 2", "item 3"}
+)
 
 func loop() {
+	scrollingWindow()
+
 	giu.Window("window").Layout(
 		giu.Align(giu.AlignCenter).To(
 			giu.Label("I'm a centered label"),
@@ -31,6 +37,30 @@ func loop() {
 				giu.Button("button 2"),
 			),
 		),
+		giu.Label("Combo in the center:"),
+		giu.Align(giu.AlignCenter).To(
+			giu.Combo("##combo", items[selected], items, &selected).Size(150),
+		),
+		giu.Child().Border(true).Size(-1, 100).Layout(
+			giu.Align(giu.AlignCenter).VAlign(giu.VAlignMiddle).To(
+				giu.Label("I'm centered vertically and horizontally"),
+			),
+		),
+	)
+}
+
+// scrollingWindow shows a right-aligned button in a window with a vertical scrollbar.
+func scrollingWindow() {
+	lines := make(giu.Layout, 30)
+	for i := range lines {
+		lines[i] = giu.Labelf("line %d", i+1)
+	}
+
+	giu.Window("scrolling window").Pos(380, 20).Size(240, 200).Layout(
+		giu.Align(giu.AlignRight).To(
+			giu.Button("I'm aligned to the content's edge"),
+		),
+		lines,
 	)
 }
 
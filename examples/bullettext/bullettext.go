@@ -0,0 +1,19 @@
+package main
+
+import (
+	g "github.com/AllenDang/giu"
+)
+
+func loop() {
+	g.SingleWindow().Layout(
+		g.Label("Resize the window to see the wrapping"),
+		g.BulletText("A short item"),
+		g.BulletText("A long item, which is wrapped; its next lines are aligned with the text after the bullet").Wrapped(true),
+		g.BulletText("Not wrapped long item overflows the window instead of wrapping"),
+	)
+}
+
+func main() {
+	wnd := g.NewMasterWindow("Wrapped bullet text", 250, 300, 0)
+	wnd.Run(loop)
+}
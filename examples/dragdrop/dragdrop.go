@@ -2,45 +2,55 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 
 	g "github.com/AllenDang/giu"
-	"github.com/AllenDang/imgui-go"
 )
 
 var (
 	dropTarget string = "Drop here"
+	fruits            = []string{"Apple", "Banana", "Cherry", "Durian", "Elderberry"}
 )
 
+// moveFruit moves fruit at index from to index to.
+func moveFruit(from, to int) {
+	fruit := fruits[from]
+	fruits = append(fruits[:from], fruits[from+1:]...)
+	fruits = append(fruits[:to], append([]string{fruit}, fruits[to:]...)...)
+}
+
+func buildFruits() g.Layout {
+	var layout g.Layout
+
+	for i, fruit := range fruits {
+		idx := i
+		layout = append(layout,
+			g.DragDropTarget("DND_FRUIT", func(data []byte) {
+				if from, err := strconv.Atoi(string(data)); err == nil {
+					moveFruit(from, idx)
+				}
+			}).To(
+				g.DragDropSource("DND_FRUIT", []byte(strconv.Itoa(idx))).
+					Preview(g.Label(fruit)).
+					To(g.Selectable(fruit)),
+			),
+		)
+	}
+
+	return layout
+}
+
 func loop() {
 	g.SingleWindow().Layout(
 		g.Row(
-			g.Custom(func() {
-				g.Button("Drag me: 9").Build()
-				if imgui.BeginDragDropSource() {
-					imgui.SetDragDropPayload("DND_DEMO", 9)
-					g.Label("9").Build()
-					imgui.EndDragDropSource()
-				}
-			}),
-			g.Custom(func() {
-				g.Button("Drag me: 10").Build()
-				if imgui.BeginDragDropSource() {
-					imgui.SetDragDropPayload("DND_DEMO", 10)
-					g.Label("10").Build()
-					imgui.EndDragDropSource()
-				}
-			}),
+			g.DragDropSource("DND_DEMO", []byte("9")).Preview(g.Label("9")).To(g.Button("Drag me: 9")),
+			g.DragDropSource("DND_DEMO", []byte("10")).Preview(g.Label("10")).To(g.Button("Drag me: 10")),
 		),
-		g.InputTextMultiline(&dropTarget).Size(g.Auto, g.Auto).Flags(g.InputTextFlagsReadOnly),
-		g.Custom(func() {
-			if imgui.BeginDragDropTarget() {
-				payload := imgui.AcceptDragDropPayload("DND_DEMO")
-				if payload != 0 {
-					dropTarget = fmt.Sprintf("Dropped value: %d", payload.Data())
-				}
-				imgui.EndDragDropTarget()
-			}
-		}),
+		g.DragDropTarget("DND_DEMO", func(data []byte) {
+			dropTarget = fmt.Sprintf("Dropped value: %s", data)
+		}).To(g.InputTextMultiline(&dropTarget).Size(g.Auto, 100).Flags(g.InputTextFlagsReadOnly)),
+		g.Label("Drag fruits to reorder them:"),
+		buildFruits(),
 	)
 }
 
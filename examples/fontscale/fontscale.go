@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/AllenDang/giu"
+)
+
+const (
+	scaleStep = 0.1
+	minScale  = 0.5
+	maxScale  = 3
+)
+
+func zoom(delta float32) {
+	scale := giu.GetGlobalFontScale() + delta
+	if scale < minScale || scale > maxScale {
+		return
+	}
+
+	giu.SetGlobalFontScale(scale)
+}
+
+func loop() {
+	giu.SingleWindow().Layout(
+		giu.Label("Press Ctrl+= to zoom in, Ctrl+- to zoom out and Ctrl+0 to reset"),
+		giu.Label(fmt.Sprintf("Current scale: %.1f", giu.GetGlobalFontScale())),
+		giu.Button("Reset").OnClick(func() {
+			giu.SetGlobalFontScale(1)
+		}),
+	)
+}
+
+func main() {
+	wnd := giu.NewMasterWindow("Font scale", 640, 480, 0).
+		RegisterKeyboardShortcuts(
+			giu.WindowShortcut{Key: giu.KeyEqual, Modifier: giu.ModControl, Callback: func() { zoom(scaleStep) }},
+			giu.WindowShortcut{Key: giu.KeyMinus, Modifier: giu.ModControl, Callback: func() { zoom(-scaleStep) }},
+			giu.WindowShortcut{Key: giu.Key0, Modifier: giu.ModControl, Callback: func() { giu.SetGlobalFontScale(1) }},
+		)
+
+	wnd.Run(loop)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AllenDang/giu"
+)
+
+var (
+	logs       string
+	autoScroll = true
+)
+
+func tail() {
+	ticker := time.NewTicker(time.Millisecond * 500)
+
+	for n := 1; ; n++ {
+		logs += fmt.Sprintf("%s: log line %d\n", time.Now().Format("15:04:05.000"), n)
+		giu.Update()
+
+		<-ticker.C
+	}
+}
+
+func loop() {
+	giu.SingleWindow().Layout(
+		giu.Checkbox("Auto-scroll", &autoScroll),
+		giu.InputTextMultiline(&logs).
+			Size(-1, -1).
+			Flags(giu.InputTextFlagsReadOnly).
+			AutoScrollToBottom(autoScroll),
+	)
+}
+
+func main() {
+	wnd := giu.NewMasterWindow("Log tail", 640, 480, 0)
+
+	go tail()
+
+	wnd.Run(loop)
+}
@@ -20,6 +20,9 @@ var (
 	autoCompleteCandidates           = []string{"hello", "hello world"}
 	date                   time.Time = time.Now()
 	col                              = &color.RGBA{}
+	login                  string
+	password               string
+	search                 string
 )
 
 func btnClickMeClicked() {
@@ -58,6 +61,7 @@ func loop() {
 		),
 		g.Label("One line label"),
 		g.Label("Auto wrapped label with very long line...............................................this line should be wrapped.").Wrapped(true),
+		g.Label("Hover me (i)").Tooltip("Labels can show extra context in a tooltip"),
 		g.Label("right/left click me"),
 		g.Event().
 			OnClick(g.MouseButtonLeft, func() { fmt.Println("I was left-clicked") }).
@@ -79,6 +83,17 @@ func loop() {
 			),
 		),
 		g.InputText(&name).Label("Input text with auto complete, input hw and press enter").Size(300).AutoComplete(autoCompleteCandidates),
+		g.Row(
+			g.InputText(&login).Hint("Login").Size(150),
+			g.InputText(&password).Hint("Password").Size(150).PasswordReveal(),
+			g.Button("Log in").OnClick(func() {
+				fmt.Println("Logging in as", login)
+			}),
+		),
+		g.InputText(&search).Hint("Search").Size(200).ClearButton().OnChange(func() {
+			fmt.Println("Searching for", search)
+		}),
+		g.SelectableText("error: connection refused (select me and press Ctrl+C to copy)"),
 		g.DatePicker("Date Picker", &date).OnChange(func() {
 			fmt.Println(date)
 		}),